@@ -8,8 +8,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/creativefabrica/vat"
+	"github.com/creativefabrica/vat/internal/redact"
 )
 
 const ServiceBaseURL = "https://abr.business.gov.au/abrxmlsearch/AbrXmlSearch.asmx/"
@@ -26,6 +29,13 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithBaseURL overrides the ABR web services base URL.
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
 type ClientOption func(*Client)
 
 func NewClient(guid string, options ...ClientOption) *Client {
@@ -42,24 +52,32 @@ func NewClient(guid string, options ...ClientOption) *Client {
 }
 
 func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
+	_, err := c.ValidateDetailed(ctx, id)
+
+	return err
+}
+
+// ValidateDetailed returns whether the given ABN is valid or not,
+// together with the entity name and location the ABR holds for it.
+func (c *Client) ValidateDetailed(ctx context.Context, id vat.IDNumber) (vat.ValidationResult, error) {
+	result := vat.ValidationResult{ID: id, Provider: vat.ProviderABR}
+
 	v := url.Values{}
 	v.Add("searchString", id.Number)
 	v.Add("includeHistoricalDetails", "N")
 	v.Add("authenticationGuid", c.guid)
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		c.baseURL+"/SearchByABNv202001?"+v.Encode(),
-		nil,
-	)
+	// The query holds the ABN and the authentication GUID, so it is left out of errors.
+	endpoint := c.baseURL + "/SearchByABNv202001"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+v.Encode(), nil)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, redact.URL(err, endpoint))
 	}
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, redact.URL(err, endpoint))
 	}
 	defer func() {
 		_ = res.Body.Close()
@@ -67,33 +85,78 @@ func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	if res.StatusCode == http.StatusBadRequest {
-		return vat.ErrInvalidFormat
+		return result, vat.ErrInvalidFormat
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return vat.ErrServiceUnavailable
+		return result, vat.ErrServiceUnavailable
 	}
 
 	var resp apiResponse
 	err = xml.Unmarshal(body, &resp)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	if resp.IsException() {
-		return resp.Response.Exception.Error()
+		return result, resp.Response.Exception.Error()
+	}
+
+	result.Valid = true
+	result.Outcome = vat.OutcomeRegistryConfirmed
+	result.RequestDate, _ = time.Parse(time.RFC3339Nano, resp.Response.DateTimeRetrieved)
+	if entity := resp.Response.BusinessEntity; entity != nil {
+		result.Name = entity.name()
+		result.Address = entity.MainBusinessPhysicalAddress.String()
 	}
 
-	return nil
+	return result, nil
 }
 
 type response struct {
-	UsageStatement string     `xml:"usageStatement,omitempty"`
-	Exception      *exception `xml:"exception,omitempty"`
+	UsageStatement    string          `xml:"usageStatement,omitempty"`
+	DateTimeRetrieved string          `xml:"dateTimeRetrieved,omitempty"`
+	BusinessEntity    *businessEntity `xml:"businessEntity202001,omitempty"`
+	Exception         *exception      `xml:"exception,omitempty"`
+}
+
+type businessEntity struct {
+	MainName struct {
+		OrganisationName string `xml:"organisationName"`
+	} `xml:"mainName"`
+	LegalName struct {
+		GivenName      string `xml:"givenName"`
+		OtherGivenName string `xml:"otherGivenName"`
+		FamilyName     string `xml:"familyName"`
+	} `xml:"legalName"`
+	MainBusinessPhysicalAddress physicalAddress `xml:"mainBusinessPhysicalAddress"`
+}
+
+// name returns the organisation name, or the legal name for sole traders.
+func (e *businessEntity) name() string {
+	if e.MainName.OrganisationName != "" {
+		return e.MainName.OrganisationName
+	}
+
+	return strings.Join(strings.Fields(strings.Join([]string{
+		e.LegalName.GivenName,
+		e.LegalName.OtherGivenName,
+		e.LegalName.FamilyName,
+	}, " ")), " ")
+}
+
+// physicalAddress is the only part of the address the ABR publishes.
+type physicalAddress struct {
+	StateCode string `xml:"stateCode"`
+	Postcode  string `xml:"postcode"`
+}
+
+func (a physicalAddress) String() string {
+	return strings.TrimSpace(a.StateCode + " " + a.Postcode)
 }
 
 type apiResponse struct {
@@ -1,10 +1,14 @@
 package abn_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/creativefabrica/vat"
 	"github.com/creativefabrica/vat/abn"
@@ -38,3 +42,47 @@ func TestClient_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ValidateDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "51824753556", r.URL.Query().Get("searchString"))
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<ABRPayloadSearchResults xmlns="http://abr.business.gov.au/ABRXMLSearch/">
+  <response>
+    <usageStatement>The Registrar of the ABR monitors the quality of the information.</usageStatement>
+    <dateTimeRetrieved>2025-05-07T19:08:55.1234567+10:00</dateTimeRetrieved>
+    <businessEntity202001>
+      <mainName><organisationName>AUSTRALIAN TAXATION OFFICE</organisationName></mainName>
+      <mainBusinessPhysicalAddress><stateCode>ACT</stateCode><postcode>2600</postcode></mainBusinessPhysicalAddress>
+    </businessEntity202001>
+  </response>
+</ABRPayloadSearchResults>`))
+	}))
+	defer server.Close()
+
+	c := abn.NewClient("guid", abn.WithBaseURL(server.URL))
+	got, err := c.ValidateDetailed(t.Context(), vat.MustParse("AU51824753556"))
+	require.NoError(t, err)
+	assert.Equal(t, vat.ValidationResult{
+		ID:          vat.MustParse("AU51824753556"),
+		Valid:       true,
+		Outcome:     vat.OutcomeRegistryConfirmed,
+		Name:        "AUSTRALIAN TAXATION OFFICE",
+		Address:     "ACT 2600",
+		RequestDate: time.Date(2025, 5, 7, 19, 8, 55, 123456700, time.FixedZone("", 10*3600)),
+		Provider:    vat.ProviderABR,
+	}, got)
+}
+
+func TestClient_ValidateDetailed_ErrorsDoNotLeakQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	c := abn.NewClient("secret-guid", abn.WithBaseURL(server.URL))
+	_, err := c.ValidateDetailed(t.Context(), vat.MustParse("AU51824753556"))
+	require.ErrorIs(t, err, vat.ErrServiceUnavailable)
+	assert.NotContains(t, err.Error(), "51824753556")
+	assert.NotContains(t, err.Error(), "secret-guid")
+}
@@ -0,0 +1,86 @@
+package vat
+
+import "strings"
+
+// canonicalCountryCode resolves prefix aliases, e.g. GR to EL.
+func canonicalCountryCode(countryCode string) string {
+	if spec, ok := lookupSpec(countryCode); ok {
+		return spec.Code
+	}
+
+	return countryCode
+}
+
+// ISOCountryCode returns the ISO 3166 alpha-2 code of the country of a VAT prefix,
+// e.g. GR for EL and GB for XI. Other prefixes are returned as is.
+func ISOCountryCode(countryCode string) string {
+	if spec, ok := lookupSpec(countryCode); ok && spec.ISOCode != "" {
+		return spec.ISOCode
+	}
+
+	return countryCode
+}
+
+// CountryCodeFromISO returns the VAT prefix of a country given its ISO 3166 alpha-2 code,
+// e.g. EL for GR. Other codes are returned as is; note that GB is returned for GB, not XI.
+func CountryCodeFromISO(iso string) string {
+	return canonicalCountryCode(iso)
+}
+
+// ISOCountryCode returns the ISO 3166 alpha-2 code of the country that issued the number.
+func (id IDNumber) ISOCountryCode() string {
+	return ISOCountryCode(id.CountryCode)
+}
+
+// Canonical returns the form of the number registries use, resolving the aliases Parse accepts:
+// GR becomes EL, 9-digit Belgian numbers get their leading 0, Austrian numbers get their U back,
+// the separators and MWST suffix of Swiss numbers are removed, Norwegian numbers get their MVA suffix,
+// the slash between the INN and KPP of Russian numbers and the dash of US EINs are removed.
+// XI numbers are kept as is, as the prefix tells whether the trader is registered in Northern Ireland.
+// Scheme is set from the country and number, as Parse does, for numbers built without it.
+func (id IDNumber) Canonical() IDNumber {
+	id.CountryCode = canonicalCountryCode(strings.ToUpper(id.CountryCode))
+	id.Number = strings.ToUpper(id.Number)
+
+	switch id.CountryCode {
+	case "AT":
+		if !strings.HasPrefix(id.Number, "U") {
+			id.Number = "U" + id.Number
+		}
+	case "BE":
+		if len(id.Number) == 9 {
+			id.Number = "0" + id.Number
+		}
+	case "CH":
+		id.Number = strings.TrimSuffix(id.Number, "MWST")
+		id.Number = strings.NewReplacer("-", "", ".", "").Replace(id.Number)
+	case "NO":
+		if !strings.HasSuffix(id.Number, "MVA") {
+			id.Number += "MVA"
+		}
+	case "RU":
+		id.Number = strings.ReplaceAll(id.Number, "/", "")
+	case "US":
+		id.Number = strings.ReplaceAll(id.Number, "-", "")
+	}
+
+	id.Scheme = schemeOf(id.CountryCode, id.Number)
+
+	return id
+}
+
+// Equal reports whether id and other refer to the same registration, comparing their canonical forms.
+// GB and XI numbers with the same VRN are equal, as HMRC issues a single number for both.
+func (id IDNumber) Equal(other IDNumber) bool {
+	return id.equalityKey() == other.equalityKey()
+}
+
+// equalityKey returns the canonical form of id, with XI numbers turned into GB ones.
+func (id IDNumber) equalityKey() IDNumber {
+	id = id.Canonical()
+	if id.CountryCode == "XI" {
+		id.CountryCode = "GB"
+	}
+
+	return id
+}
@@ -0,0 +1,131 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestParse_Aliases(t *testing.T) {
+	tests := []struct {
+		input string
+		want  vat.IDNumber
+	}{
+		{input: "GR094259216", want: vat.IDNumber{CountryCode: "GR", Number: "094259216"}},
+		{input: "BE403019261", want: vat.IDNumber{CountryCode: "BE", Number: "403019261"}},
+		{input: "AT13585627", want: vat.IDNumber{CountryCode: "AT", Number: "13585627"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := vat.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIDNumber_Canonical(t *testing.T) {
+	tests := []struct {
+		name string
+		id   vat.IDNumber
+		want vat.IDNumber
+	}{
+		{
+			name: "greek ISO code",
+			id:   vat.IDNumber{CountryCode: "GR", Number: "094259216"},
+			want: vat.IDNumber{CountryCode: "EL", Number: "094259216"},
+		},
+		{
+			name: "old belgian number",
+			id:   vat.IDNumber{CountryCode: "BE", Number: "403019261"},
+			want: vat.IDNumber{CountryCode: "BE", Number: "0403019261"},
+		},
+		{
+			name: "austrian number without U",
+			id:   vat.IDNumber{CountryCode: "AT", Number: "13585627"},
+			want: vat.IDNumber{CountryCode: "AT", Number: "U13585627"},
+		},
+		{
+			name: "swiss number with separators",
+			id:   vat.IDNumber{CountryCode: "CH", Number: "E-116.281.710MWST"},
+			want: vat.IDNumber{CountryCode: "CH", Number: "E116281710"},
+		},
+		{
+			name: "norwegian number without MVA",
+			id:   vat.IDNumber{CountryCode: "NO", Number: "974760673"},
+			want: vat.IDNumber{CountryCode: "NO", Number: "974760673MVA"},
+		},
+		{
+			name: "russian INN and KPP",
+			id:   vat.IDNumber{CountryCode: "RU", Number: "7707083893/773601001"},
+			want: vat.IDNumber{CountryCode: "RU", Number: "7707083893773601001"},
+		},
+		{
+			name: "US EIN without scheme",
+			id:   vat.IDNumber{CountryCode: "US", Number: "52-1234567"},
+			want: vat.IDNumber{CountryCode: "US", Number: "521234567", Scheme: vat.SchemeEIN},
+		},
+		{
+			name: "canadian GST/HST account without scheme",
+			id:   vat.IDNumber{CountryCode: "CA", Number: "123456782RT0001"},
+			want: vat.IDNumber{CountryCode: "CA", Number: "123456782RT0001", Scheme: vat.SchemeGSTHST},
+		},
+		{
+			name: "lowercase",
+			id:   vat.IDNumber{CountryCode: "nl", Number: "822010690b01"},
+			want: vat.IDNumber{CountryCode: "NL", Number: "822010690B01"},
+		},
+		{
+			name: "northern ireland",
+			id:   vat.IDNumber{CountryCode: "XI", Number: "980780684"},
+			want: vat.IDNumber{CountryCode: "XI", Number: "980780684"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.id.Canonical())
+		})
+	}
+}
+
+func TestIDNumber_Equal(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "GR094259216", b: "EL094259216", want: true},
+		{a: "BE403019261", b: "BE0403019261", want: true},
+		{a: "AT13585627", b: "ATU13585627", want: true},
+		{a: "CHE-116.281.710 MWST", b: "CHE116281710", want: true},
+		{a: "NO974760673", b: "NO 974 760 673 MVA", want: true},
+		{a: "RU7707083893/773601001", b: "RU7707083893773601001", want: true},
+		{a: "US52-1234567", b: "US521234567", want: true},
+		{a: "XI980780684", b: "GB980780684", want: true},
+		{a: "GB980780684", b: "GB980780684001", want: false},
+		{a: "DE136695976", b: "NL822010690B01", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, vat.MustParse(tt.a).Equal(vat.MustParse(tt.b)))
+		})
+	}
+}
+
+func TestIDNumber_Equal_Literal(t *testing.T) {
+	assert.True(t, vat.IDNumber{CountryCode: "US", Number: "521234567"}.Equal(vat.MustParse("US521234567")))
+	assert.True(t, vat.IDNumber{CountryCode: "CA", Number: "123456782"}.Equal(vat.MustParse("CA123456782")))
+	assert.True(t, vat.IDNumber{CountryCode: "GR", Number: "094259216"}.Equal(vat.MustParse("EL094259216")))
+	assert.False(t, vat.IDNumber{CountryCode: "CA", Number: "123456782"}.Equal(vat.MustParse("CA123456782RT0001")))
+}
+
+func TestISOCountryCode(t *testing.T) {
+	assert.Equal(t, "GR", vat.ISOCountryCode("EL"))
+	assert.Equal(t, "GB", vat.ISOCountryCode("XI"))
+	assert.Equal(t, "DE", vat.ISOCountryCode("DE"))
+	assert.Equal(t, "EL", vat.CountryCodeFromISO("GR"))
+	assert.Equal(t, "GB", vat.CountryCodeFromISO("GB"))
+	assert.Equal(t, "GR", vat.MustParse("EL094259216").ISOCountryCode())
+}
@@ -0,0 +1,731 @@
+//nolint:mnd // Check digit algorithms are defined in terms of their weights and moduli.
+package vat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// checksums maps the names used in country specs to the function verifying the check digits of a number
+// that already matched the country pattern. Country specific algorithms are named after the lowercase
+// VAT prefix and expect numbers matching the pattern of that country.
+// Most of them follow the descriptions at https://ec.europa.eu/taxation_customs/tin/
+// and the implementations of https://github.com/arthurdejong/python-stdnum.
+//
+//nolint:gochecknoglobals // This is a constant map of names to check digit algorithms.
+var checksums = map[string]func(number string) bool{
+	"luhn":             validLuhn,
+	"iso7064-mod11-10": validMod11_10,
+	"iso7064-mod97-10": validMod97_10,
+	"at":               validAT,
+	"au":               validaABN,
+	"ba":               validBA,
+	"be":               validBE,
+	"bg":               validBG,
+	"by":               validBY,
+	"ca":               validCA,
+	"ch":               validCH,
+	"cy":               validCY,
+	"cz":               validCZ,
+	"de":               validDE,
+	"dk":               validDK,
+	"ee":               validEE,
+	"el":               validEL,
+	"es":               validES,
+	"fi":               validFI,
+	"fr":               validFR,
+	"gb":               validGB,
+	"hr":               validHR,
+	"hu":               validHU,
+	"ie":               validIE,
+	"is":               validIS,
+	"it":               validIT,
+	"kz":               validKZ,
+	"lt":               validLT,
+	"lu":               validLU,
+	"lv":               validLV,
+	"md":               validMD,
+	"me":               validME,
+	"mk":               validMK,
+	"mt":               validMT,
+	"mx":               validMX,
+	"nl":               validNL,
+	"no":               validNO,
+	"pl":               validPL,
+	"pt":               validPT,
+	"ro":               validRO,
+	"ru":               validRU,
+	"se":               validSE,
+	"si":               validSI,
+	"sk":               validSK,
+	"ua":               validUA,
+}
+
+// isDigits reports whether s is non-empty and only contains ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// digit returns the value of the ASCII digit at s[i].
+func digit(s string, i int) int {
+	return int(s[i] - '0')
+}
+
+// weightedSum multiplies every digit of s with the weight at the same position and adds them up.
+func weightedSum(s string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += w * digit(s, i)
+	}
+
+	return sum
+}
+
+// luhnChecksum returns the Luhn checksum of s, which is 0 for valid numbers.
+func luhnChecksum(s string) int {
+	sum := 0
+	for i := range len(s) {
+		d := digit(s, len(s)-1-i)
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return sum % 10
+}
+
+// luhnCheckDigit returns the digit that makes s followed by it pass the Luhn check.
+func luhnCheckDigit(s string) int {
+	return (10 - luhnChecksum(s+"0")) % 10
+}
+
+// mod11_10 returns the ISO 7064 Mod 11, 10 checksum of s, which is 1 for valid numbers.
+func mod11_10(s string) int {
+	check := 5
+	for i := range len(s) {
+		if check == 0 {
+			check = 10
+		}
+		check = (check*2%11 + digit(s, i)) % 10
+	}
+
+	return check
+}
+
+// mod97 returns the ISO 7064 Mod 97, 10 remainder of s, where letters count as 10 to 35.
+func mod97(s string) int {
+	rem := 0
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+
+	return rem
+}
+
+// atoi converts a string of digits to an int, the caller is expected to have checked the input.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+
+	return n
+}
+
+// validDate reports whether the year, month and day make up an existing date.
+func validDate(year, month, day int) bool {
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+	return date.Day() == day && date.Month() == time.Month(month) && date.Year() == year
+}
+
+// validLuhn checks numbers whose last digit is a Luhn check digit.
+func validLuhn(number string) bool {
+	return isDigits(number) && luhnChecksum(number) == 0
+}
+
+// validMod11_10 checks numbers whose last digit is an ISO 7064 Mod 11, 10 check digit.
+func validMod11_10(number string) bool {
+	return isDigits(number) && mod11_10(number) == 1
+}
+
+// validMod97_10 checks numbers whose last two digits are ISO 7064 Mod 97, 10 check digits.
+func validMod97_10(number string) bool {
+	return mod97(number) == 1
+}
+
+func validAT(number string) bool {
+	// U followed by 7 digits and a check digit
+	number = strings.TrimPrefix(number, "U")
+	if !isDigits(number) {
+		return false
+	}
+
+	return (6-luhnChecksum(number[:7])+10)%10 == digit(number, 7)
+}
+
+// validBA checks the JIB, whose leading 4 VAT numbers drop.
+func validBA(number string) bool {
+	if len(number) == 12 {
+		number = "4" + number
+	}
+
+	remainder := weightedSum(number, []int{7, 6, 5, 4, 3, 2, 7, 6, 5, 4, 3, 2}) % 11
+
+	return remainder != 1 && (11-remainder)%11 == digit(number, 12)
+}
+
+func validBE(number string) bool {
+	if len(number) == 9 {
+		number = "0" + number
+	}
+
+	if number[0] > '1' {
+		return false
+	}
+
+	return 97-atoi(number[:8])%97 == atoi(number[8:])
+}
+
+func validBG(number string) bool {
+	if len(number) == 9 {
+		check := 0
+		for i := range 8 {
+			check += (i + 1) * digit(number, i)
+		}
+		check %= 11
+		if check == 10 {
+			check = 0
+			for i := range 8 {
+				check += (i + 3) * digit(number, i)
+			}
+			check %= 11
+		}
+
+		return check%10 == digit(number, 8)
+	}
+
+	// Natural persons (EGN), foreigners (PNF) or other entities.
+	egn := weightedSum(number, []int{2, 4, 8, 5, 10, 9, 7, 3, 6}) % 11 % 10
+	pnf := weightedSum(number, []int{21, 19, 17, 13, 11, 9, 7, 3, 1}) % 10
+	other := (11 - weightedSum(number, []int{4, 3, 2, 7, 6, 5, 4, 3, 2})%11) % 11
+
+	last := digit(number, 9)
+
+	return egn == last || pnf == last || other == last
+}
+
+// byAlphabet gives the values of the characters of Belarusian UNP numbers.
+const byAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+func validBY(number string) bool {
+	// Letters count as 10 to 35, except for the second character of the UNP of natural
+	// persons, which stands for its index in ABCEHKMOPT.
+	first := strings.IndexByte(byAlphabet, number[0])
+	second := strings.IndexByte("ABCEHKMOPT", number[1])
+	if second < 0 {
+		second = digit(number, 1)
+	}
+
+	check := (29*first + 23*second + weightedSum(number[2:], []int{19, 17, 13, 7, 5, 3})) % 11
+
+	return check < 10 && check == digit(number, 8)
+}
+
+func validCA(number string) bool {
+	if len(number) == qstLength {
+		// QST numbers have no published check digit.
+		return number[12:] != "0000"
+	}
+
+	// The business number, optionally followed by a program account whose reference number starts at 0001
+	return luhnChecksum(number[:bnLength]) == 0 && (len(number) == bnLength || number[11:] != "0000")
+}
+
+func validCH(number string) bool {
+	// E followed by 8 digits and a check digit, with optional separators and suffix
+	var digits [9]int
+	n := 0
+	for i := range len(number) {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+
+		if n == len(digits) {
+			return false
+		}
+
+		digits[n] = digit(number, i)
+		n++
+	}
+
+	if n != len(digits) {
+		return false
+	}
+
+	sum := 0
+	for i, w := range []int{5, 4, 3, 2, 7, 6, 5, 4} {
+		sum += w * digits[i]
+	}
+
+	check := (11 - sum%11) % 11
+
+	return check != 10 && check == digits[8]
+}
+
+func validCY(number string) bool {
+	if number[:2] == "12" {
+		return false
+	}
+
+	translation := [10]int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21}
+	sum := 0
+	for i := range 8 {
+		if i%2 == 0 {
+			sum += translation[digit(number, i)]
+		} else {
+			sum += digit(number, i)
+		}
+	}
+
+	return byte('A'+sum%26) == number[8]
+}
+
+func validCZ(number string) bool {
+	switch {
+	case len(number) == 8:
+		// Legal entities
+		if number[0] == '9' {
+			return false
+		}
+		check := (11 - weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2})%11) % 11
+		if check == 0 {
+			check = 1
+		}
+
+		return check%10 == digit(number, 7)
+	case len(number) == 9 && number[0] == '6':
+		// Individuals without a birth number
+		check := weightedSum(number[1:], []int{8, 7, 6, 5, 4, 3, 2}) % 11
+
+		return ((8-(10-check)%11)%10+10)%10 == digit(number, 8)
+	case len(number) == 9:
+		// Birth numbers issued before 1954 have no check digit.
+		return true
+	default:
+		// Birth numbers
+		check := atoi(number[:9]) % 11
+		if check == 10 {
+			check = 0
+		}
+
+		return check == digit(number, 9)
+	}
+}
+
+func validDE(number string) bool {
+	return number[0] != '0' && mod11_10(number) == 1
+}
+
+func validDK(number string) bool {
+	return number[0] != '0' && weightedSum(number, []int{2, 7, 6, 5, 4, 3, 2, 1})%11 == 0
+}
+
+func validEE(number string) bool {
+	return (10-weightedSum(number, []int{3, 7, 1, 3, 7, 1, 3, 7})%10)%10 == digit(number, 8)
+}
+
+func validEL(number string) bool {
+	check := 0
+	for i := range 8 {
+		check = check*2 + digit(number, i)
+	}
+
+	return check*2%11%10 == digit(number, 8)
+}
+
+// dniLetters are the check letters of Spanish DNI and NIE numbers, indexed by the number modulo 23.
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+
+func validES(number string) bool {
+	first := number[0]
+	switch {
+	case first >= '0' && first <= '9':
+		// DNI
+		return isDigits(number[:8]) && dniLetters[atoi(number[:8])%23] == number[8]
+	case strings.IndexByte("XYZ", first) >= 0:
+		// NIE, the leading letter stands for a digit
+		n := string('0'+first-'X') + number[1:8]
+
+		return isDigits(n) && dniLetters[atoi(n)%23] == number[8]
+	case strings.IndexByte("KLM", first) >= 0:
+		// Special NIF for Spanish nationals without a DNI
+		return isDigits(number[1:8]) && dniLetters[atoi(number[1:8])%23] == number[8]
+	case strings.IndexByte("ABCDEFGHJNPQRSUVW", first) >= 0:
+		// CIF, the letter tells the legal form and the check character is either a digit or a letter
+		if !isDigits(number[1:8]) {
+			return false
+		}
+		check := luhnCheckDigit(number[1:8])
+
+		return number[8] == byte('0'+check) || number[8] == "JABCDEFGHI"[check]
+	default:
+		return false
+	}
+}
+
+func validFI(number string) bool {
+	return weightedSum(number, []int{7, 9, 10, 5, 8, 4, 2, 1})%11 == 0
+}
+
+// frAlphabet is used for the alphanumeric check keys of French VAT numbers.
+const frAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+func validFR(number string) bool {
+	siren := number[2:]
+	// SIREN numbers starting with 000 are issued in Monaco and do not carry a Luhn check digit.
+	if siren[:3] != "000" && luhnChecksum(siren) != 0 {
+		return false
+	}
+
+	if isDigits(number[:2]) {
+		return atoi(number[:2]) == atoi(siren+"12")%97
+	}
+
+	// New style alphanumeric keys, see https://www.impots.gouv.fr/
+	c0 := strings.IndexByte(frAlphabet, number[0])
+	c1 := strings.IndexByte(frAlphabet, number[1])
+	if c0 < 0 || c1 < 0 {
+		return false
+	}
+
+	check := c0*34 + c1 - 100
+	if c0 < 10 {
+		check = c0*24 + c1 - 10
+	}
+
+	return (atoi(siren)+1+check/11)%11 == check%11
+}
+
+func validGB(number string) bool {
+	if !isDigits(number) {
+		// Government departments and health authorities have no check digits.
+		return true
+	}
+
+	// Branch traders add a 3 digit branch code to the standard number.
+	sum := weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2, 10, 1}) % 97
+	if atoi(number[:3]) < 100 {
+		// Numbers issued before 2010 use the mod 97 check only.
+		return sum == 0
+	}
+
+	// Newer numbers use mod 9755, which adds 55 to the sum before the mod 97 check.
+	return sum == 0 || sum == 42 || sum == 55
+}
+
+func validHR(number string) bool {
+	return mod11_10(number) == 1
+}
+
+func validHU(number string) bool {
+	return weightedSum(number, []int{9, 7, 3, 1, 9, 7, 3, 1})%10 == 0
+}
+
+// ieAlphabet holds the check characters of Irish VAT numbers.
+const ieAlphabet = "WABCDEFGHIJKLMNOPQRSTUV"
+
+func validIE(number string) bool {
+	if number[0] >= '0' && number[0] <= '9' && number[1] >= 'A' && number[1] <= 'Z' {
+		// Old style numbers like 1X23456T are converted to the new style.
+		number = "0" + number[2:7] + number[:1] + number[7:]
+	}
+
+	if !isDigits(number[:7]) {
+		return false
+	}
+
+	sum := weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2})
+	if len(number) == 9 {
+		// The 9th character is a letter from A to I, or W, which counts as 0.
+		ninth := strings.IndexByte(ieAlphabet, number[8])
+		if ninth < 0 || ninth > 9 {
+			return false
+		}
+		sum += 9 * ninth
+	}
+
+	return ieAlphabet[sum%23] == number[7]
+}
+
+func validIS(number string) bool {
+	if len(number) != 10 {
+		// VSK numbers have no check digit.
+		return true
+	}
+
+	// Kennitala: birth or registration date, 2 random digits, a check digit and the century.
+	var century int
+	switch number[9] {
+	case '8':
+		century = 1800
+	case '9':
+		century = 1900
+	case '0':
+		century = 2000
+	default:
+		return false
+	}
+
+	day, month, year := atoi(number[:2]), atoi(number[2:4]), century+atoi(number[4:6])
+	if day > 40 {
+		// Organisations add 40 to the day.
+		day -= 40
+	}
+
+	if !validDate(year, month, day) {
+		return false
+	}
+
+	return weightedSum(number, []int{3, 2, 7, 6, 5, 4, 3, 2, 1})%11 == 0
+}
+
+func validIT(number string) bool {
+	if number[:7] == "0000000" {
+		return false
+	}
+
+	// The province office code must be between 001 and 100, or one of the special codes.
+	office := atoi(number[7:10])
+	if (office < 1 || office > 100) && office != 120 && office != 121 && office != 888 && office != 999 {
+		return false
+	}
+
+	return luhnChecksum(number) == 0
+}
+
+func validKZ(number string) bool {
+	// BIN and IIN share the same check digit, which is recalculated with other weights when it is 10.
+	check := weightedSum(number, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}) % 11
+	if check == 10 {
+		check = weightedSum(number, []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 1, 2}) % 11
+	}
+
+	return check < 10 && check == digit(number, 11)
+}
+
+func validLT(number string) bool {
+	// Legal entities have a 1 right before the check digit.
+	if number[len(number)-2] != '1' {
+		return false
+	}
+
+	body := number[:len(number)-1]
+	check := 0
+	for i := range len(body) {
+		check += (1 + i%9) * digit(body, i)
+	}
+	check %= 11
+	if check == 10 {
+		check = 0
+		for i := range len(body) {
+			check += (1 + (i+2)%9) * digit(body, i)
+		}
+		check %= 11
+	}
+
+	return check%10 == digit(number, len(number)-1)
+}
+
+func validLU(number string) bool {
+	return atoi(number[:6])%89 == atoi(number[6:])
+}
+
+func validLV(number string) bool {
+	if number[0] > '3' {
+		// Legal entities
+		return weightedSum(number, []int{9, 1, 4, 8, 3, 10, 2, 5, 7, 6, 1})%11 == 3
+	}
+
+	if number[:2] == "32" {
+		// Personal codes issued since 2017 carry no birth date and no check digit.
+		return true
+	}
+
+	// Personal codes
+	return (1+weightedSum(number, []int{10, 5, 8, 4, 2, 1, 6, 3, 7, 9}))%11%10 == digit(number, 10)
+}
+
+func validMD(number string) bool {
+	return weightedSum(number, []int{7, 3, 1, 7, 3, 1, 7, 3, 1, 7, 3, 1})%10 == digit(number, 12)
+}
+
+func validME(number string) bool {
+	return (11-weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2})%11)%11%10 == digit(number, 7)
+}
+
+func validMK(number string) bool {
+	return (11-weightedSum(number, []int{7, 6, 5, 4, 3, 2, 7, 6, 5, 4, 3, 2})%11)%11%10 == digit(number, 12)
+}
+
+func validMT(number string) bool {
+	return number[0] != '0' && weightedSum(number, []int{3, 4, 6, 7, 8, 9, 10, 1})%37 == 0
+}
+
+// mxAlphabet gives the values of the characters of Mexican RFC numbers. The space pads those of companies.
+const mxAlphabet = "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ"
+
+const (
+	// mxGenericRFC is the number invoices to the general public are issued to, which has no valid check digit.
+	mxGenericRFC = "XAXX010101000"
+	// mxForeignRFC is the number invoices to foreign customers are issued to.
+	mxForeignRFC = "XEXX010101000"
+)
+
+func validMX(number string) bool {
+	if number == mxGenericRFC {
+		return true
+	}
+
+	// The letters are followed by the date of incorporation or birth, and the century is not part of the number.
+	date := number[len(number)-9 : len(number)-3]
+	year, month, day := atoi(date[:2]), atoi(date[2:4]), atoi(date[4:])
+	if !validDate(1900+year, month, day) && !validDate(2000+year, month, day) {
+		return false
+	}
+
+	// Companies have 3 letters and natural persons 4, so the weight of the first character is the length.
+	weight := utf8.RuneCountInString(number)
+	sum := 0
+	if weight == 12 {
+		sum = 13 * strings.IndexByte(mxAlphabet, ' ')
+	}
+
+	for _, r := range number[:len(number)-1] {
+		sum += weight * strings.IndexRune(mxAlphabet, r)
+		weight--
+	}
+
+	return mxAlphabet[(11-sum%11)%11] == number[len(number)-1]
+}
+
+func validNL(number string) bool {
+	if number[10:] == "00" {
+		return false
+	}
+
+	// Companies use the RSIN with an 11-proof check, sole proprietors have used
+	// a number with an ISO 7064 Mod 97, 10 check since 2020.
+	return (weightedSum(number, []int{9, 8, 7, 6, 5, 4, 3, 2, -1})%11+11)%11 == 0 || mod97("NL"+number) == 1
+}
+
+func validNO(number string) bool {
+	// The organisation number, optionally followed by MVA
+	return weightedSum(number, []int{3, 2, 7, 6, 5, 4, 3, 2, 1})%11 == 0
+}
+
+func validPL(number string) bool {
+	return weightedSum(number, []int{6, 5, 7, 2, 3, 4, 5, 6, 7, -1})%11 == 0
+}
+
+func validPT(number string) bool {
+	if number[0] == '0' {
+		return false
+	}
+
+	return (11-weightedSum(number, []int{9, 8, 7, 6, 5, 4, 3, 2})%11)%11%10 == digit(number, 8)
+}
+
+func validRO(number string) bool {
+	padded := strings.Repeat("0", 10-len(number)) + number
+
+	return 10*weightedSum(padded, []int{7, 5, 3, 2, 1, 7, 5, 3, 2})%11%10 == digit(padded, 9)
+}
+
+func validRU(number string) bool {
+	if len(number) == 12 {
+		// INN of natural persons, with two check digits
+		return weightedSum(number, []int{7, 2, 4, 10, 3, 5, 9, 4, 6, 8})%11%10 == digit(number, 10) &&
+			weightedSum(number, []int{3, 7, 2, 4, 10, 3, 5, 9, 4, 6, 8})%11%10 == digit(number, 11)
+	}
+
+	// INN of legal entities, optionally followed by the KPP, which has no check digit
+	return weightedSum(number, []int{2, 4, 10, 3, 5, 9, 4, 6, 8})%11%10 == digit(number, 9)
+}
+
+func validSE(number string) bool {
+	// The organisation number followed by 01
+	return number[10:] == "01" && luhnChecksum(number[:10]) == 0
+}
+
+func validSI(number string) bool {
+	if number[0] == '0' {
+		return false
+	}
+
+	check := 11 - weightedSum(number, []int{8, 7, 6, 5, 4, 3, 2})%11
+	if check == 10 {
+		check = 0
+	}
+
+	return check == digit(number, 7)
+}
+
+func validSK(number string) bool {
+	// The first digit is not 0 and the third digit is one of 2, 3, 4, 7, 8 or 9
+	if number[0] == '0' || strings.IndexByte("234789", number[2]) < 0 {
+		return false
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+
+	return err == nil && n%11 == 0
+}
+
+func validUA(number string) bool {
+	switch len(number) {
+	case 8:
+		// EDRPOU of legal entities, whose weights are rotated for numbers between 30000000 and 60000000
+		weights := []int{1, 2, 3, 4, 5, 6, 7}
+		if number[0] >= '3' && number[0] <= '5' {
+			weights = []int{7, 1, 2, 3, 4, 5, 6}
+		}
+
+		check := weightedSum(number, weights) % 11
+		if check == 10 {
+			for i := range weights {
+				weights[i] += 2
+			}
+			check = weightedSum(number, weights) % 11 % 10
+		}
+
+		return check == digit(number, 7)
+	case 10:
+		// RNTRC of natural persons
+		return (weightedSum(number, []int{-1, 5, 7, 9, 4, 6, 10, 5, 7})%11+11)%11%10 == digit(number, 9)
+	default:
+		// VAT IPN, which has no published check digit
+		return true
+	}
+}
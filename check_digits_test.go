@@ -0,0 +1,45 @@
+package vat_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+// checkDigitCase lists numbers of a country passing and failing its check digits. The cases are kept in
+// testdata/check_digits.json, which cmd/vatgen also turns into test vectors for the TypeScript module.
+type checkDigitCase struct {
+	Country string   `json:"country"`
+	Valid   []string `json:"valid"`
+	Invalid []string `json:"invalid"`
+}
+
+func TestParse_CheckDigits(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "check_digits.json"))
+	require.NoError(t, err)
+
+	var tests []checkDigitCase
+	require.NoError(t, json.Unmarshal(data, &tests))
+	require.NotEmpty(t, tests)
+
+	for _, tt := range tests {
+		t.Run(tt.Country, func(t *testing.T) {
+			for _, s := range tt.Valid {
+				_, err := vat.Parse(s)
+				assert.NoError(t, err, s)
+			}
+
+			for _, s := range tt.Invalid {
+				_, err := vat.Parse(s)
+				require.ErrorIs(t, err, vat.ErrInvalidCheckDigits, s)
+				require.ErrorIs(t, err, vat.ErrInvalidFormat, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Command vatgen exports the country specs Parse uses, so that frontends validate VAT numbers
+// with the same rules as the backend. It writes a JSON Schema, an OpenAPI 3.1 fragment and
+// a TypeScript module with the patterns and check digit algorithms of every country, and test vectors
+// holding the result of Parse for the examples of every country, variants of them and the numbers
+// of the check digit tests, which the tests of vatgen run the TypeScript module on.
+//
+// It is run by go generate from the root of the module:
+//
+//	go run ./cmd/vatgen -schema generated/vat.schema.json -openapi generated/openapi.json -ts generated/vat.ts \
+//		-vectors generated/vectors.json -check-digits testdata/check_digits.json
+//
+// Outputs whose flag is empty are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/creativefabrica/vat"
+)
+
+// rule is a country spec with its patterns converted for ECMAScript.
+type rule struct {
+	vat.CountrySpec
+
+	// Prefixes are the prefixes Parse accepts for the country, Code first.
+	Prefixes []string
+	// NumberPattern matches the number without the prefix.
+	NumberPattern string
+	// FullPattern matches the number with one of Prefixes.
+	FullPattern string
+	// fullExpr is FullPattern without the anchors, in Go syntax.
+	fullExpr string
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "write the JSON Schema to `file`")
+	openAPIPath := flag.String("openapi", "", "write the OpenAPI fragment to `file`")
+	tsPath := flag.String("ts", "", "write the TypeScript module to `file`")
+	vectorsPath := flag.String("vectors", "", "write the test vectors to `file`")
+	checkDigitsPath := flag.String("check-digits", "", "add the numbers of the check digit cases in `file` to the vectors")
+	flag.Parse()
+
+	if err := run(*schemaPath, *openAPIPath, *tsPath, *vectorsPath, *checkDigitsPath); err != nil {
+		fmt.Fprintln(os.Stderr, "vatgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, openAPIPath, tsPath, vectorsPath, checkDigitsPath string) error {
+	rules, err := newRules(vat.CountrySpecs())
+	if err != nil {
+		return err
+	}
+
+	checkDigitInputs, err := readCheckDigitInputs(checkDigitsPath)
+	if err != nil {
+		return err
+	}
+
+	outputs := []struct {
+		path     string
+		generate func([]rule) ([]byte, error)
+	}{
+		{schemaPath, jsonSchema},
+		{openAPIPath, openAPI},
+		{tsPath, typeScript},
+		{vectorsPath, func(rules []rule) ([]byte, error) { return vectors(rules, checkDigitInputs) }},
+	}
+	for _, out := range outputs {
+		if out.path == "" {
+			continue
+		}
+
+		data, err := out.generate(rules)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(out.path, data, 0o644); err != nil { //nolint:gosec // Generated files are public.
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newRules converts specs, sorted by code, to rules.
+func newRules(specs []vat.CountrySpec) ([]rule, error) {
+	codes := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		codes[spec.Code] = true
+	}
+
+	rules := make([]rule, 0, len(specs))
+	for _, spec := range specs {
+		r := rule{CountrySpec: spec, Prefixes: []string{spec.Code}}
+		// Like Parse, accept the ISO code unless it is a VAT prefix itself.
+		if spec.ISOCode != "" && !codes[spec.ISOCode] {
+			r.Prefixes = append(r.Prefixes, spec.ISOCode)
+		}
+
+		expr, err := spec.NumberRegexp()
+		if err != nil {
+			return nil, err
+		}
+
+		if r.NumberPattern, err = ecmaRegexp(expr); err != nil {
+			return nil, fmt.Errorf("%s: %w", spec.Code, err)
+		}
+
+		// NumberRegexp is anchored with ^ and $.
+		r.fullExpr = "(?:" + strings.Join(r.Prefixes, "|") + ")" + strings.TrimSuffix(strings.TrimPrefix(expr, "^"), "$")
+		if r.FullPattern, err = ecmaRegexp("^" + r.fullExpr + "$"); err != nil {
+			return nil, fmt.Errorf("%s: %w", spec.Code, err)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// anyPattern returns the ECMAScript pattern matching the numbers of every rule.
+func anyPattern(rules []rule) (string, error) {
+	exprs := make([]string, 0, len(rules))
+	for _, r := range rules {
+		exprs = append(exprs, r.fullExpr)
+	}
+
+	return ecmaRegexp("^(?:" + strings.Join(exprs, "|") + ")$")
+}
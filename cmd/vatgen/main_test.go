@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	rules, err := newRules(vat.CountrySpecs())
+	require.NoError(t, err)
+
+	checkDigitInputs, err := readCheckDigitInputs(filepath.Join("..", "..", "testdata", "check_digits.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, checkDigitInputs)
+
+	outputs := map[string]func([]rule) ([]byte, error){
+		"vat.schema.json": jsonSchema,
+		"openapi.json":    openAPI,
+		"vat.ts":          typeScript,
+		"vectors.json":    func(rules []rule) ([]byte, error) { return vectors(rules, checkDigitInputs) },
+	}
+	for name, generate := range outputs {
+		t.Run(name, func(t *testing.T) {
+			want, err := generate(rules)
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join("..", "..", "generated", name))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "generated/%s is out of date, run go generate", name)
+		})
+	}
+}
+
+// vectorsScript runs parse from the TypeScript module given as first argument on the vectors given as second,
+// printing those whose result differs from the one of Parse.
+const vectorsScript = `
+const { readFileSync } = await import("node:fs");
+const { pathToFileURL } = await import("node:url");
+const { parse } = await import(pathToFileURL(process.argv[1]).href);
+
+for (const v of JSON.parse(readFileSync(process.argv[2], "utf8"))) {
+  const want = v.valid ? { countryCode: v.countryCode, number: v.number, scheme: v.scheme ?? "" } : null;
+  const got = parse(v.input);
+  if (JSON.stringify(got) !== JSON.stringify(want)) {
+    console.log(v.input + ": got " + JSON.stringify(got) + ", want " + JSON.stringify(want));
+  }
+}
+`
+
+// TestTypeScript_Vectors checks that the generated TypeScript module agrees with Parse on the test vectors.
+// It needs a Node.js version able to strip types, 22.6 or later, and is skipped otherwise.
+func TestTypeScript_Vectors(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+
+	if err := exec.Command(node, "--experimental-strip-types", "-e", "").Run(); err != nil {
+		t.Skip("node can't run TypeScript, 22.6 or later is required")
+	}
+
+	dir := filepath.Join("..", "..", "generated")
+	args := []string{
+		"--experimental-strip-types", "--no-warnings", "--input-type=module",
+		"-e", vectorsScript, filepath.Join(dir, "vat.ts"), filepath.Join(dir, "vectors.json"),
+	}
+	out, err := exec.Command(node, args...).Output() //nolint:gosec // The arguments are constants.
+	require.NoError(t, err, "%s", out)
+	assert.Empty(t, string(out), "generated/vat.ts disagrees with Parse")
+}
+
+func TestNewRules(t *testing.T) {
+	rules, err := newRules([]vat.CountrySpec{
+		{Code: "EL", ISOCode: "GR", Name: "Greece", Lengths: []vat.LengthRange{{Min: 9, Max: 9}}, Characters: "0-9"},
+		{Code: "GB", Name: "United Kingdom", Pattern: "[0-9]{9}|GD[0-4][0-9]{2}"},
+		{Code: "XI", ISOCode: "GB", Name: "Northern Ireland", Pattern: "[0-9]{9}"},
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+
+	assert.Equal(t, []string{"EL", "GR"}, rules[0].Prefixes)
+	assert.Equal(t, "^[0-9]{9}$", rules[0].NumberPattern)
+	assert.Equal(t, "^(?:EL|GR)[0-9]{9}$", rules[0].FullPattern)
+	assert.Equal(t, "^GB(?:[0-9]{9}|GD[0-4][0-9]{2})$", rules[1].FullPattern)
+	// GB is a VAT prefix itself, so it is not an alias of XI.
+	assert.Equal(t, []string{"XI"}, rules[2].Prefixes)
+
+	pattern, err := anyPattern(rules)
+	require.NoError(t, err)
+	assert.Equal(t, "^(?:(?:EL|GR)[0-9]{9}|GB(?:[0-9]{9}|GD[0-4][0-9]{2})|XI[0-9]{9})$", pattern)
+}
+
+func TestEcmaRegexp(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    string
+		wantErr bool
+	}{
+		{expr: `^(?:U?[0-9]{8})$`, want: `^U?[0-9]{8}$`},
+		{expr: `E-?[0-9]{3}\.?(?:MWST)?`, want: `E-?[0-9]{3}\.?(?:MWST)?`},
+		{expr: `[0-9A-HJ-NP-Z]{2}[0-9]{9,}`, want: `[0-9A-HJ-NP-Z]{2}[0-9]{9,}`},
+		{expr: `[+/-]\d+?.`, want: `[\+\-\/][0-9]+?[^\n]`},
+		{expr: `(?s).É`, want: `[\s\S]\u00C9`},
+		{expr: `(?i)ab`, wantErr: true},
+		{expr: `[^a]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ecmaRegexp(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTypeScript_MissingChecksum(t *testing.T) {
+	_, err := typeScript([]rule{{CountrySpec: vat.CountrySpec{Code: "QZ", Checksum: "qz"}}})
+	assert.ErrorContains(t, err, "qz")
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// maxBMPRune is the last character that fits in a single UTF-16 code unit.
+const maxBMPRune = 0xFFFF
+
+// ecmaRegexp converts a Go regexp to the ECMAScript syntax used by JSON Schema patterns and TypeScript,
+// matching the same strings without the u flag.
+func ecmaRegexp(expr string) (string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := writeECMA(&b, re); err != nil {
+		return "", fmt.Errorf("%s: %w", expr, err)
+	}
+
+	return b.String(), nil
+}
+
+func writeECMA(b *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		b.WriteString(`[^\s\S]`)
+	case syntax.OpEmptyMatch:
+		b.WriteString(`(?:)`)
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return fmt.Errorf("case-insensitive literal %s is not supported", re)
+		}
+
+		for _, r := range re.Rune {
+			if err := writeECMARune(b, r, false); err != nil {
+				return err
+			}
+		}
+	case syntax.OpCharClass:
+		b.WriteByte('[')
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if err := writeECMARune(b, re.Rune[i], true); err != nil {
+				return err
+			}
+
+			if re.Rune[i+1] != re.Rune[i] {
+				b.WriteByte('-')
+				if err := writeECMARune(b, re.Rune[i+1], true); err != nil {
+					return err
+				}
+			}
+		}
+		b.WriteByte(']')
+	case syntax.OpAnyCharNotNL:
+		// The ECMAScript dot also excludes \r and the Unicode line and paragraph separators.
+		b.WriteString(`[^\n]`)
+	case syntax.OpAnyChar:
+		b.WriteString(`[\s\S]`)
+	case syntax.OpBeginText:
+		b.WriteByte('^')
+	case syntax.OpEndText:
+		b.WriteByte('$')
+	case syntax.OpWordBoundary:
+		b.WriteString(`\b`)
+	case syntax.OpNoWordBoundary:
+		b.WriteString(`\B`)
+	case syntax.OpCapture:
+		return writeECMAGroup(b, re.Sub...)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		return writeECMARepeat(b, re)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writeECMA(b, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		return writeECMAGroup(b, re.Sub...)
+	case syntax.OpBeginLine, syntax.OpEndLine:
+		return fmt.Errorf("multi-line assertion %s is not supported", re)
+	default:
+		return fmt.Errorf("%s is not supported", re)
+	}
+
+	return nil
+}
+
+// writeECMAGroup writes the alternatives in a non-capturing group.
+func writeECMAGroup(b *strings.Builder, alternatives ...*syntax.Regexp) error {
+	b.WriteString("(?:")
+	for i, sub := range alternatives {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+
+		if err := writeECMA(b, sub); err != nil {
+			return err
+		}
+	}
+	b.WriteByte(')')
+
+	return nil
+}
+
+func writeECMARepeat(b *strings.Builder, re *syntax.Regexp) error {
+	sub := re.Sub[0]
+	atom := sub.Op == syntax.OpCharClass || sub.Op == syntax.OpAnyChar || sub.Op == syntax.OpAnyCharNotNL ||
+		sub.Op == syntax.OpLiteral && len(sub.Rune) == 1
+
+	var err error
+	if atom {
+		err = writeECMA(b, sub)
+	} else {
+		err = writeECMAGroup(b, sub)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case re.Op == syntax.OpStar:
+		b.WriteByte('*')
+	case re.Op == syntax.OpPlus:
+		b.WriteByte('+')
+	case re.Op == syntax.OpQuest:
+		b.WriteByte('?')
+	case re.Max == re.Min:
+		fmt.Fprintf(b, "{%d}", re.Min)
+	case re.Max < 0:
+		fmt.Fprintf(b, "{%d,}", re.Min)
+	default:
+		fmt.Fprintf(b, "{%d,%d}", re.Min, re.Max)
+	}
+
+	if re.Flags&syntax.NonGreedy != 0 {
+		b.WriteByte('?')
+	}
+
+	return nil
+}
+
+// writeECMARune writes r escaped so that it stands for itself, with or without the u flag.
+func writeECMARune(b *strings.Builder, r rune, inClass bool) error {
+	switch {
+	case r > maxBMPRune:
+		return fmt.Errorf("character %U outside of the basic multilingual plane is not supported", r)
+	case strings.ContainsRune(`\^$.|?*+()[]{}/`, r) || inClass && r == '-':
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	case r < ' ' || r > '~':
+		fmt.Fprintf(b, `\u%04X`, r)
+	default:
+		b.WriteRune(r)
+	}
+
+	return nil
+}
@@ -0,0 +1,672 @@
+// Parsing and check digit algorithms, ported from parse_options.go, id_number.go, scheme.go and check_digits.go.
+
+/** A VAT number split into its prefix and number, like the Go IDNumber. */
+export interface VATNumber {
+  countryCode: string;
+  number: string;
+  /** The kind of tax identifier, like the Go Scheme: empty for VAT numbers, e.g. "us_ein" for others. */
+  scheme: string;
+}
+
+/**
+ * Parses a VAT number like the Go Parse: spaces are removed, letters are uppercased,
+ * and both the format and the check digits are verified. It returns null for invalid numbers.
+ */
+export function parse(input: string): VATNumber | null {
+  const s = input.toUpperCase().replace(/ /g, "");
+  if ([...s].length < 3) {
+    return null;
+  }
+
+  const countryCode = s.slice(0, 2);
+  const rule = countries[aliases[countryCode] ?? countryCode];
+  if (rule === undefined) {
+    return null;
+  }
+
+  const number = s.slice(2);
+  if (!rule.pattern.test(number)) {
+    return null;
+  }
+
+  if (rule.checksum !== undefined && !checksums[rule.checksum](number)) {
+    return null;
+  }
+
+  return { countryCode, number, scheme: schemeOf(aliases[countryCode] ?? countryCode, number) };
+}
+
+function schemeOf(countryCode: string, number: string): string {
+  switch (countryCode) {
+    case "CA":
+      if (number.length === 16) {
+        return "ca_qst";
+      }
+
+      return number.slice(9, 11) === "RT" ? "ca_gst_hst" : "ca_bn";
+    case "US":
+      return "us_ein";
+    case "MX":
+      return "mx_rfc";
+    default:
+      return "";
+  }
+}
+
+/** Reports whether parse accepts the input. */
+export function isValid(input: string): boolean {
+  return parse(input) !== null;
+}
+
+function isDigits(s: string): boolean {
+  return /^[0-9]+$/.test(s);
+}
+
+function digit(s: string, i: number): number {
+  return s.charCodeAt(i) - 48;
+}
+
+function weightedSum(s: string, weights: number[]): number {
+  let sum = 0;
+  weights.forEach((w, i) => {
+    sum += w * digit(s, i);
+  });
+
+  return sum;
+}
+
+function luhnChecksum(s: string): number {
+  let sum = 0;
+  for (let i = 0; i < s.length; i++) {
+    let d = digit(s, s.length - 1 - i);
+    if (i % 2 === 1) {
+      d *= 2;
+      if (d > 9) {
+        d -= 9;
+      }
+    }
+    sum += d;
+  }
+
+  return sum % 10;
+}
+
+function luhnCheckDigit(s: string): number {
+  return (10 - luhnChecksum(s + "0")) % 10;
+}
+
+function mod11_10(s: string): number {
+  let check = 5;
+  for (let i = 0; i < s.length; i++) {
+    if (check === 0) {
+      check = 10;
+    }
+    check = (((check * 2) % 11) + digit(s, i)) % 10;
+  }
+
+  return check;
+}
+
+function mod97(s: string): number {
+  let rem = 0;
+  for (let i = 0; i < s.length; i++) {
+    const c = s.charCodeAt(i);
+    if (c >= 48 && c <= 57) {
+      rem = (rem * 10 + c - 48) % 97;
+    } else if (c >= 65 && c <= 90) {
+      rem = (rem * 100 + c - 65 + 10) % 97;
+    } else {
+      return -1;
+    }
+  }
+
+  return rem;
+}
+
+function atoi(s: string): number {
+  return parseInt(s, 10);
+}
+
+function validDate(year: number, month: number, day: number): boolean {
+  const date = new Date(Date.UTC(year, month - 1, day));
+
+  return date.getUTCDate() === day && date.getUTCMonth() === month - 1 && date.getUTCFullYear() === year;
+}
+
+function validLuhn(number: string): boolean {
+  return isDigits(number) && luhnChecksum(number) === 0;
+}
+
+function validMod11_10(number: string): boolean {
+  return isDigits(number) && mod11_10(number) === 1;
+}
+
+function validMod97_10(number: string): boolean {
+  return mod97(number) === 1;
+}
+
+function validAT(number: string): boolean {
+  if (number.startsWith("U")) {
+    number = number.slice(1);
+  }
+  if (!isDigits(number)) {
+    return false;
+  }
+
+  return (6 - luhnChecksum(number.slice(0, 7)) + 10) % 10 === digit(number, 7);
+}
+
+function validAU(abn: string): boolean {
+  if (abn[0] === "0" || abn.length !== 11) {
+    return false;
+  }
+
+  // The first digit is decreased by one before the weighted sum.
+  const weights = [10, 1, 3, 5, 7, 9, 11, 13, 15, 17, 19];
+
+  return (weightedSum(abn, weights) - weights[0]) % 89 === 0;
+}
+
+function validBA(number: string): boolean {
+  if (number.length === 12) {
+    number = "4" + number;
+  }
+  const remainder = weightedSum(number, [7, 6, 5, 4, 3, 2, 7, 6, 5, 4, 3, 2]) % 11;
+  return remainder !== 1 && (11 - remainder) % 11 === digit(number, 12);
+}
+
+function validBE(number: string): boolean {
+  if (number.length === 9) {
+    number = "0" + number;
+  }
+  if (number[0] > "1") {
+    return false;
+  }
+
+  return 97 - (atoi(number.slice(0, 8)) % 97) === atoi(number.slice(8));
+}
+
+function validBG(number: string): boolean {
+  if (number.length === 9) {
+    let check = 0;
+    for (let i = 0; i < 8; i++) {
+      check += (i + 1) * digit(number, i);
+    }
+    check %= 11;
+    if (check === 10) {
+      check = 0;
+      for (let i = 0; i < 8; i++) {
+        check += (i + 3) * digit(number, i);
+      }
+      check %= 11;
+    }
+
+    return check % 10 === digit(number, 8);
+  }
+
+  const egn = (weightedSum(number, [2, 4, 8, 5, 10, 9, 7, 3, 6]) % 11) % 10;
+  const pnf = weightedSum(number, [21, 19, 17, 13, 11, 9, 7, 3, 1]) % 10;
+  const other = (11 - (weightedSum(number, [4, 3, 2, 7, 6, 5, 4, 3, 2]) % 11)) % 11;
+  const last = digit(number, 9);
+
+  return egn === last || pnf === last || other === last;
+}
+
+function validBY(number: string): boolean {
+  const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ";
+  let second = "ABCEHKMOPT".indexOf(number[1]);
+  if (second < 0) {
+    second = digit(number, 1);
+  }
+
+  const check =
+    (29 * alphabet.indexOf(number[0]) + 23 * second + weightedSum(number.slice(2), [19, 17, 13, 7, 5, 3])) % 11;
+
+  return check < 10 && check === digit(number, 8);
+}
+
+function validCA(number: string): boolean {
+  if (number.length === 16) {
+    return number.slice(12) !== "0000";
+  }
+
+  return luhnChecksum(number.slice(0, 9)) === 0 && (number.length === 9 || number.slice(11) !== "0000");
+}
+
+function validCH(number: string): boolean {
+  const digits = number.replace(/[^0-9]/g, "");
+  if (digits.length !== 9) {
+    return false;
+  }
+
+  const check = (11 - (weightedSum(digits, [5, 4, 3, 2, 7, 6, 5, 4]) % 11)) % 11;
+
+  return check !== 10 && check === digit(digits, 8);
+}
+
+function validCY(number: string): boolean {
+  if (number.slice(0, 2) === "12") {
+    return false;
+  }
+
+  const translation = [1, 0, 5, 7, 9, 13, 15, 17, 19, 21];
+  let sum = 0;
+  for (let i = 0; i < 8; i++) {
+    sum += i % 2 === 0 ? translation[digit(number, i)] : digit(number, i);
+  }
+
+  return String.fromCharCode(65 + (sum % 26)) === number[8];
+}
+
+function validCZ(number: string): boolean {
+  if (number.length === 8) {
+    if (number[0] === "9") {
+      return false;
+    }
+    let check = (11 - (weightedSum(number, [8, 7, 6, 5, 4, 3, 2]) % 11)) % 11;
+    if (check === 0) {
+      check = 1;
+    }
+
+    return check % 10 === digit(number, 7);
+  }
+  if (number.length === 9 && number[0] === "6") {
+    const check = weightedSum(number.slice(1), [8, 7, 6, 5, 4, 3, 2]) % 11;
+
+    return ((((8 - ((10 - check) % 11)) % 10) + 10) % 10) === digit(number, 8);
+  }
+  if (number.length === 9) {
+    return true;
+  }
+
+  let check = atoi(number.slice(0, 9)) % 11;
+  if (check === 10) {
+    check = 0;
+  }
+
+  return check === digit(number, 9);
+}
+
+function validDE(number: string): boolean {
+  return number[0] !== "0" && mod11_10(number) === 1;
+}
+
+function validDK(number: string): boolean {
+  return number[0] !== "0" && weightedSum(number, [2, 7, 6, 5, 4, 3, 2, 1]) % 11 === 0;
+}
+
+function validEE(number: string): boolean {
+  return (10 - (weightedSum(number, [3, 7, 1, 3, 7, 1, 3, 7]) % 10)) % 10 === digit(number, 8);
+}
+
+function validEL(number: string): boolean {
+  let check = 0;
+  for (let i = 0; i < 8; i++) {
+    check = check * 2 + digit(number, i);
+  }
+
+  return ((check * 2) % 11) % 10 === digit(number, 8);
+}
+
+const dniLetters = "TRWAGMYFPDXBNJZSQVHLCKE";
+
+function validES(number: string): boolean {
+  const first = number[0];
+  if (first >= "0" && first <= "9") {
+    const n = number.slice(0, 8);
+
+    return isDigits(n) && dniLetters[atoi(n) % 23] === number[8];
+  }
+  if ("XYZ".includes(first)) {
+    const n = String.fromCharCode(48 + first.charCodeAt(0) - 88) + number.slice(1, 8);
+
+    return isDigits(n) && dniLetters[atoi(n) % 23] === number[8];
+  }
+  if ("KLM".includes(first)) {
+    const n = number.slice(1, 8);
+
+    return isDigits(n) && dniLetters[atoi(n) % 23] === number[8];
+  }
+  if (!"ABCDEFGHJNPQRSUVW".includes(first)) {
+    return false;
+  }
+
+  const n = number.slice(1, 8);
+  if (!isDigits(n)) {
+    return false;
+  }
+  const check = luhnCheckDigit(n);
+
+  return number[8] === String(check) || number[8] === "JABCDEFGHI"[check];
+}
+
+function validFI(number: string): boolean {
+  return weightedSum(number, [7, 9, 10, 5, 8, 4, 2, 1]) % 11 === 0;
+}
+
+const frAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ";
+
+function validFR(number: string): boolean {
+  const siren = number.slice(2);
+  if (siren.slice(0, 3) !== "000" && luhnChecksum(siren) !== 0) {
+    return false;
+  }
+
+  if (isDigits(number.slice(0, 2))) {
+    return atoi(number.slice(0, 2)) === atoi(siren + "12") % 97;
+  }
+
+  const c0 = frAlphabet.indexOf(number[0]);
+  const c1 = frAlphabet.indexOf(number[1]);
+  if (c0 < 0 || c1 < 0) {
+    return false;
+  }
+
+  const check = c0 < 10 ? c0 * 24 + c1 - 10 : c0 * 34 + c1 - 100;
+
+  return (atoi(siren) + 1 + Math.trunc(check / 11)) % 11 === check % 11;
+}
+
+function validGB(number: string): boolean {
+  if (!isDigits(number)) {
+    return true;
+  }
+
+  const sum = weightedSum(number, [8, 7, 6, 5, 4, 3, 2, 10, 1]) % 97;
+  if (atoi(number.slice(0, 3)) < 100) {
+    return sum === 0;
+  }
+
+  return sum === 0 || sum === 42 || sum === 55;
+}
+
+function validHR(number: string): boolean {
+  return mod11_10(number) === 1;
+}
+
+function validHU(number: string): boolean {
+  return weightedSum(number, [9, 7, 3, 1, 9, 7, 3, 1]) % 10 === 0;
+}
+
+const ieAlphabet = "WABCDEFGHIJKLMNOPQRSTUV";
+
+function validIE(number: string): boolean {
+  if (number[0] >= "0" && number[0] <= "9" && number[1] >= "A" && number[1] <= "Z") {
+    number = "0" + number.slice(2, 7) + number.slice(0, 1) + number.slice(7);
+  }
+  if (!isDigits(number.slice(0, 7))) {
+    return false;
+  }
+
+  let sum = weightedSum(number, [8, 7, 6, 5, 4, 3, 2]);
+  if (number.length === 9) {
+    const ninth = ieAlphabet.indexOf(number[8]);
+    if (ninth < 0 || ninth > 9) {
+      return false;
+    }
+    sum += 9 * ninth;
+  }
+
+  return ieAlphabet[sum % 23] === number[7];
+}
+
+function validIS(number: string): boolean {
+  if (number.length !== 10) {
+    return true;
+  }
+
+  const century = { "8": 1800, "9": 1900, "0": 2000 }[number[9]];
+  if (century === undefined) {
+    return false;
+  }
+
+  let day = atoi(number.slice(0, 2));
+  const month = atoi(number.slice(2, 4));
+  const year = century + atoi(number.slice(4, 6));
+  if (day > 40) {
+    day -= 40;
+  }
+
+  if (!validDate(year, month, day)) {
+    return false;
+  }
+
+  return weightedSum(number, [3, 2, 7, 6, 5, 4, 3, 2, 1]) % 11 === 0;
+}
+
+function validIT(number: string): boolean {
+  if (number.slice(0, 7) === "0000000") {
+    return false;
+  }
+
+  const office = atoi(number.slice(7, 10));
+  if ((office < 1 || office > 100) && office !== 120 && office !== 121 && office !== 888 && office !== 999) {
+    return false;
+  }
+
+  return luhnChecksum(number) === 0;
+}
+
+function validKZ(number: string): boolean {
+  let check = weightedSum(number, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11]) % 11;
+  if (check === 10) {
+    check = weightedSum(number, [3, 4, 5, 6, 7, 8, 9, 10, 11, 1, 2]) % 11;
+  }
+
+  return check < 10 && check === digit(number, 11);
+}
+
+function validLT(number: string): boolean {
+  if (number[number.length - 2] !== "1") {
+    return false;
+  }
+
+  const body = number.slice(0, -1);
+  let check = 0;
+  for (let i = 0; i < body.length; i++) {
+    check += (1 + (i % 9)) * digit(body, i);
+  }
+  check %= 11;
+  if (check === 10) {
+    check = 0;
+    for (let i = 0; i < body.length; i++) {
+      check += (1 + ((i + 2) % 9)) * digit(body, i);
+    }
+    check %= 11;
+  }
+
+  return check % 10 === digit(number, number.length - 1);
+}
+
+function validLU(number: string): boolean {
+  return atoi(number.slice(0, 6)) % 89 === atoi(number.slice(6));
+}
+
+function validLV(number: string): boolean {
+  if (number[0] > "3") {
+    return weightedSum(number, [9, 1, 4, 8, 3, 10, 2, 5, 7, 6, 1]) % 11 === 3;
+  }
+  if (number.slice(0, 2) === "32") {
+    return true;
+  }
+
+  return ((1 + weightedSum(number, [10, 5, 8, 4, 2, 1, 6, 3, 7, 9])) % 11) % 10 === digit(number, 10);
+}
+
+function validMD(number: string): boolean {
+  return weightedSum(number, [7, 3, 1, 7, 3, 1, 7, 3, 1, 7, 3, 1]) % 10 === digit(number, 12);
+}
+
+function validME(number: string): boolean {
+  return ((11 - (weightedSum(number, [8, 7, 6, 5, 4, 3, 2]) % 11)) % 11) % 10 === digit(number, 7);
+}
+
+function validMK(number: string): boolean {
+  return (
+    ((11 - (weightedSum(number, [7, 6, 5, 4, 3, 2, 7, 6, 5, 4, 3, 2]) % 11)) % 11) % 10 === digit(number, 12)
+  );
+}
+
+function validMT(number: string): boolean {
+  return number[0] !== "0" && weightedSum(number, [3, 4, 6, 7, 8, 9, 10, 1]) % 37 === 0;
+}
+
+const mxAlphabet = "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ";
+
+function validMX(number: string): boolean {
+  if (number === "XAXX010101000") {
+    return true;
+  }
+
+  const date = number.slice(-9, -3);
+  const year = atoi(date.slice(0, 2));
+  const month = atoi(date.slice(2, 4));
+  const day = atoi(date.slice(4));
+  if (!validDate(1900 + year, month, day) && !validDate(2000 + year, month, day)) {
+    return false;
+  }
+
+  // Ñ is a single UTF-16 code unit, so the length counts characters like the Go code counts runes.
+  let weight = number.length;
+  let sum = weight === 12 ? 13 * mxAlphabet.indexOf(" ") : 0;
+  for (const c of number.slice(0, -1)) {
+    sum += weight * mxAlphabet.indexOf(c);
+    weight--;
+  }
+
+  return mxAlphabet[(11 - (sum % 11)) % 11] === number[number.length - 1];
+}
+
+function validNL(number: string): boolean {
+  if (number.slice(10) === "00") {
+    return false;
+  }
+
+  return (
+    ((weightedSum(number, [9, 8, 7, 6, 5, 4, 3, 2, -1]) % 11) + 11) % 11 === 0 || mod97("NL" + number) === 1
+  );
+}
+
+function validNO(number: string): boolean {
+  return weightedSum(number, [3, 2, 7, 6, 5, 4, 3, 2, 1]) % 11 === 0;
+}
+
+function validPL(number: string): boolean {
+  return weightedSum(number, [6, 5, 7, 2, 3, 4, 5, 6, 7, -1]) % 11 === 0;
+}
+
+function validPT(number: string): boolean {
+  if (number[0] === "0") {
+    return false;
+  }
+
+  return ((11 - (weightedSum(number, [9, 8, 7, 6, 5, 4, 3, 2]) % 11)) % 11) % 10 === digit(number, 8);
+}
+
+function validRO(number: string): boolean {
+  const padded = number.padStart(10, "0");
+
+  return ((10 * weightedSum(padded, [7, 5, 3, 2, 1, 7, 5, 3, 2])) % 11) % 10 === digit(padded, 9);
+}
+
+function validRU(number: string): boolean {
+  if (number.length === 12) {
+    return (
+      (weightedSum(number, [7, 2, 4, 10, 3, 5, 9, 4, 6, 8]) % 11) % 10 === digit(number, 10) &&
+      (weightedSum(number, [3, 7, 2, 4, 10, 3, 5, 9, 4, 6, 8]) % 11) % 10 === digit(number, 11)
+    );
+  }
+
+  return (weightedSum(number, [2, 4, 10, 3, 5, 9, 4, 6, 8]) % 11) % 10 === digit(number, 9);
+}
+
+function validSE(number: string): boolean {
+  return number.slice(10) === "01" && luhnChecksum(number.slice(0, 10)) === 0;
+}
+
+function validSI(number: string): boolean {
+  if (number[0] === "0") {
+    return false;
+  }
+
+  let check = 11 - (weightedSum(number, [8, 7, 6, 5, 4, 3, 2]) % 11);
+  if (check === 10) {
+    check = 0;
+  }
+
+  return check === digit(number, 7);
+}
+
+function validSK(number: string): boolean {
+  return number[0] !== "0" && "234789".includes(number[2]) && isDigits(number) && Number(number) % 11 === 0;
+}
+
+function validUA(number: string): boolean {
+  if (number.length === 8) {
+    let weights = [1, 2, 3, 4, 5, 6, 7];
+    if (number[0] >= "3" && number[0] <= "5") {
+      weights = [7, 1, 2, 3, 4, 5, 6];
+    }
+
+    let check = weightedSum(number, weights) % 11;
+    if (check === 10) {
+      check = (weightedSum(number, weights.map((w) => w + 2)) % 11) % 10;
+    }
+
+    return check === digit(number, 7);
+  }
+  if (number.length === 10) {
+    return (((weightedSum(number, [-1, 5, 7, 9, 4, 6, 10, 5, 7]) % 11) + 11) % 11) % 10 === digit(number, 9);
+  }
+
+  return true;
+}
+
+/** The check digit algorithms, by the name used in the country specs. */
+export const checksums: Record<string, (number: string) => boolean> = {
+  "luhn": validLuhn,
+  "iso7064-mod11-10": validMod11_10,
+  "iso7064-mod97-10": validMod97_10,
+  "at": validAT,
+  "au": validAU,
+  "ba": validBA,
+  "be": validBE,
+  "bg": validBG,
+  "by": validBY,
+  "ca": validCA,
+  "ch": validCH,
+  "cy": validCY,
+  "cz": validCZ,
+  "de": validDE,
+  "dk": validDK,
+  "ee": validEE,
+  "el": validEL,
+  "es": validES,
+  "fi": validFI,
+  "fr": validFR,
+  "gb": validGB,
+  "hr": validHR,
+  "hu": validHU,
+  "ie": validIE,
+  "is": validIS,
+  "it": validIT,
+  "kz": validKZ,
+  "lt": validLT,
+  "lu": validLU,
+  "lv": validLV,
+  "md": validMD,
+  "me": validME,
+  "mk": validMK,
+  "mt": validMT,
+  "mx": validMX,
+  "nl": validNL,
+  "no": validNO,
+  "pl": validPL,
+  "pt": validPT,
+  "ro": validRO,
+  "ru": validRU,
+  "se": validSE,
+  "si": validSI,
+  "sk": validSK,
+  "ua": validUA,
+};
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// schemaDescription applies to every generated schema.
+	schemaDescription = "A VAT number with its country prefix, uppercase and without spaces. " +
+		"The pattern does not verify check digits."
+)
+
+// schema is the subset of JSON Schema used for VAT numbers. Fields are in output order.
+type schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type"`
+	Pattern     string             `json:"pattern"`
+	Examples    []string           `json:"examples,omitempty"`
+	Defs        map[string]*schema `json:"$defs,omitempty"`
+}
+
+// countrySchema returns the schema of the numbers of r.
+func countrySchema(r rule) *schema {
+	description := schemaDescription
+	if r.Format != "" {
+		description += " Expected format: " + r.Format + "."
+	}
+
+	return &schema{
+		Title:       "VAT number of " + r.Name,
+		Description: description,
+		Type:        "string",
+		Pattern:     r.FullPattern,
+		Examples:    r.Examples,
+	}
+}
+
+// allSchema returns the schema matching the numbers of every rule.
+func allSchema(rules []rule) (*schema, error) {
+	pattern, err := anyPattern(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &schema{
+		Title:       "VAT number",
+		Description: schemaDescription,
+		Type:        "string",
+		Pattern:     pattern,
+	}
+	for _, r := range rules {
+		if len(r.Examples) > 0 {
+			s.Examples = append(s.Examples, r.Examples[0])
+		}
+	}
+
+	return s, nil
+}
+
+// jsonSchema returns a JSON Schema for VAT numbers of any country, with one definition per country.
+func jsonSchema(rules []rule) ([]byte, error) {
+	s, err := allSchema(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Schema = jsonSchemaDialect
+	s.Defs = make(map[string]*schema, len(rules))
+	for _, r := range rules {
+		s.Defs[r.Code] = countrySchema(r)
+	}
+
+	return marshalJSON(s)
+}
+
+// openAPI returns an OpenAPI 3.1 fragment declaring the VATNumber schema, and VATNumberXX for every country XX,
+// to be merged into the components of an API description.
+func openAPI(rules []rule) ([]byte, error) {
+	all, err := allSchema(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := map[string]*schema{"VATNumber": all}
+	for _, r := range rules {
+		schemas["VATNumber"+r.Code] = countrySchema(r)
+	}
+
+	return marshalJSON(map[string]any{
+		"components": map[string]any{"schemas": schemas},
+	})
+}
+
+// marshalJSON indents v and keeps characters like < as is.
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
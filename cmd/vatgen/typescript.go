@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+// runtimeTS holds the parts of the TypeScript module that don't depend on the specs.
+//
+//go:embed runtime.ts
+var runtimeTS string
+
+// tsChecksumNames are the check digit algorithms runtimeTS implements.
+//
+//nolint:gochecknoglobals // This is a compiled regexp.
+var tsChecksumNames = regexp.MustCompile(`(?m)^  "([a-z0-9-]+)": valid`)
+
+//nolint:gochecknoglobals // This is a parsed template.
+var tsTemplate = template.Must(template.New("vat.ts").Funcs(template.FuncMap{"str": tsString}).Parse(
+	`// Code generated by vatgen from the country specs of github.com/creativefabrica/vat. DO NOT EDIT.
+
+/** The rules of a VAT prefix, see CountrySpec in the Go package. */
+export interface CountryRule {
+  code: string;
+  isoCode?: string;
+  name: string;
+  /** Matches the number without the prefix, once spaces are removed and letters uppercased. */
+  pattern: RegExp;
+  /** Names the check digit algorithm in checksums. */
+  checksum?: string;
+  format: string;
+  examples: string[];
+}
+
+/** The rules of every VAT prefix. */
+export const countries: Record<string, CountryRule> = {
+{{- range .}}
+  {{.Code}}: {
+    code: {{str .Code}},
+{{- if .ISOCode}}
+    isoCode: {{str .ISOCode}},
+{{- end}}
+    name: {{str .Name}},
+    pattern: new RegExp({{str .NumberPattern}}),
+{{- if .Checksum}}
+    checksum: {{str .Checksum}},
+{{- end}}
+    format: {{str .Format}},
+    examples: [{{range $i, $e := .Examples}}{{if $i}}, {{end}}{{str $e}}{{end}}],
+  },
+{{- end}}
+};
+
+/** ISO 3166 codes accepted in place of the VAT prefix of their country. */
+export const aliases: Record<string, string> = {
+{{- range .}}{{$code := .Code}}{{range slice .Prefixes 1}}
+  {{.}}: {{str $code}},
+{{- end}}{{end}}
+};
+
+`))
+
+// typeScript returns a module exporting the rules, and parse and isValid functions working like Parse.
+func typeScript(rules []rule) ([]byte, error) {
+	implemented := make(map[string]bool)
+	for _, m := range tsChecksumNames.FindAllStringSubmatch(runtimeTS, -1) {
+		implemented[m[1]] = true
+	}
+
+	for _, r := range rules {
+		if r.Checksum != "" && !implemented[r.Checksum] {
+			return nil, fmt.Errorf("%s: checksum %s has no TypeScript implementation in runtime.ts", r.Code, r.Checksum)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tsTemplate.Execute(&buf, rules); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(runtimeTS)
+
+	return buf.Bytes(), nil
+}
+
+// tsString quotes s as a TypeScript string literal.
+func tsString(s string) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
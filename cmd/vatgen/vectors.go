@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/creativefabrica/vat"
+)
+
+// vector is an input and the result Parse returns for it, for frontends to check their validation against.
+type vector struct {
+	Input       string `json:"input"`
+	Valid       bool   `json:"valid"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Number      string `json:"number,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+}
+
+// checkDigitCase lists numbers of a country passing and failing its check digits,
+// as in testdata/check_digits.json of the vat package.
+type checkDigitCase struct {
+	Country string   `json:"country"`
+	Valid   []string `json:"valid"`
+	Invalid []string `json:"invalid"`
+}
+
+// readCheckDigitInputs returns the numbers of the check digit cases in path, or none if path is empty.
+func readCheckDigitInputs(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []checkDigitCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var inputs []string
+	for _, c := range cases {
+		inputs = append(inputs, c.Valid...)
+		inputs = append(inputs, c.Invalid...)
+	}
+
+	return inputs, nil
+}
+
+// vectors returns the examples of every rule, followed by the examples with a single character changed,
+// most of which fail the check digits, and by extra inputs, with the result of Parse for each, one per line.
+func vectors(rules []rule, extra []string) ([]byte, error) {
+	var inputs []string
+	seen := make(map[string]bool)
+	add := func(input string) {
+		if !seen[input] {
+			seen[input] = true
+			inputs = append(inputs, input)
+		}
+	}
+
+	for _, r := range rules {
+		for _, example := range r.Examples {
+			add(example)
+
+			chars := []rune(example)
+			for i := len(r.Code); i < len(chars); i++ {
+				mutated := append([]rune(nil), chars...)
+				mutated[i] = nextChar(mutated[i])
+				add(string(mutated))
+			}
+		}
+	}
+
+	for _, input := range extra {
+		add(input)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("[\n")
+	for i, input := range inputs {
+		v := vector{Input: input}
+		if id, err := vat.Parse(input); err == nil {
+			v = vector{Input: input, Valid: true, CountryCode: id.CountryCode, Number: id.Number, Scheme: string(id.Scheme)}
+		}
+
+		line, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("  ")
+		buf.Write(line)
+		if i < len(inputs)-1 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString("]\n")
+
+	return buf.Bytes(), nil
+}
+
+// nextChar returns the ASCII digit or uppercase letter following c, wrapping around, and other characters as is.
+func nextChar(c rune) rune {
+	switch {
+	case c == '9':
+		return '0'
+	case c == 'Z':
+		return 'A'
+	case c >= '0' && c < '9', c >= 'A' && c < 'Z':
+		return c + 1
+	default:
+		return c
+	}
+}
@@ -0,0 +1,84 @@
+package vat
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Country describes a supported VAT prefix.
+type Country struct {
+	// Code is the VAT prefix, e.g. EL for Greece.
+	Code string
+	// ISOCode is the ISO 3166 alpha-2 code of the country, e.g. GR for Greece.
+	ISOCode string
+	// Name is the English name of the country.
+	Name string
+	// LocalName is the name of the country in its official language(s).
+	LocalName string
+	// EUJoined is the day the country joined the EU, zero if it never did.
+	EUJoined time.Time
+	// EULeft is the first day the country was no longer an EU member, zero if it still is or never was.
+	EULeft time.Time
+	// Format describes the expected format for humans: 9 stands for a digit, L for a letter,
+	// X for a letter or a digit, anything else for itself.
+	Format string
+	// Examples are valid numbers, including the prefix, for documentation and input placeholders.
+	Examples []string
+	// Providers are the services able to verify numbers of the country, the default one first.
+	Providers []Provider
+}
+
+// EUMember reports whether the country was an EU member state at t.
+func (c Country) EUMember(t time.Time) bool {
+	if c.EUJoined.IsZero() || t.Before(c.EUJoined) {
+		return false
+	}
+
+	return c.EULeft.IsZero() || t.Before(c.EULeft)
+}
+
+// Countries returns every supported VAT prefix, sorted by Code.
+// The returned values are copies, so changing them has no effect on the package.
+func Countries() []Country {
+	specs := allSpecs()
+	list := make([]Country, 0, len(specs))
+	for _, spec := range specs {
+		list = append(list, newCountry(spec))
+	}
+
+	slices.SortFunc(list, func(a, b Country) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+
+	return list
+}
+
+// LookupCountry returns the country of a VAT prefix. Aliases like GR are resolved to their prefix.
+func LookupCountry(countryCode string) (Country, bool) {
+	spec, ok := lookupSpec(countryCode)
+	if !ok {
+		return Country{}, false
+	}
+
+	return newCountry(spec), true
+}
+
+func newCountry(spec *countrySpec) Country {
+	isoCode := spec.ISOCode
+	if isoCode == "" {
+		isoCode = spec.Code
+	}
+
+	return Country{
+		Code:      spec.Code,
+		ISOCode:   isoCode,
+		Name:      spec.Name,
+		LocalName: spec.LocalName,
+		EUJoined:  spec.EUJoined,
+		EULeft:    spec.EULeft,
+		Format:    spec.Format,
+		Examples:  slices.Clone(spec.Examples),
+		Providers: slices.Clone(spec.Providers),
+	}
+}
@@ -0,0 +1,67 @@
+package vat_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestCountries(t *testing.T) {
+	countries := vat.Countries()
+	require.NotEmpty(t, countries)
+
+	for i, country := range countries {
+		t.Run(country.Code, func(t *testing.T) {
+			if i > 0 {
+				assert.Less(t, countries[i-1].Code, country.Code)
+			}
+
+			assert.Len(t, country.ISOCode, 2)
+			assert.NotEmpty(t, country.Name)
+			assert.NotEmpty(t, country.LocalName)
+			assert.NotEmpty(t, country.Format)
+			require.NotEmpty(t, country.Examples)
+
+			for _, example := range country.Examples {
+				id, err := vat.Parse(example)
+				require.NoError(t, err, example)
+				assert.Equal(t, country.Code, id.CountryCode)
+			}
+		})
+	}
+}
+
+func TestLookupCountry(t *testing.T) {
+	greece, ok := vat.LookupCountry("GR")
+	require.True(t, ok)
+	assert.Equal(t, "EL", greece.Code)
+	assert.Equal(t, "GR", greece.ISOCode)
+	assert.Equal(t, "Ελλάδα", greece.LocalName)
+	assert.Equal(t, []vat.Provider{vat.ProviderVIES}, greece.Providers)
+
+	_, ok = vat.LookupCountry("BR")
+	assert.False(t, ok)
+
+	// Changing the returned values does not change the registry.
+	greece.Examples[0] = "EL000000000"
+	greece, _ = vat.LookupCountry("EL")
+	assert.Equal(t, "EL094259216", greece.Examples[0])
+}
+
+func TestCountry_EUMember(t *testing.T) {
+	uk, _ := vat.LookupCountry("GB")
+	assert.False(t, uk.EUMember(time.Date(1972, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, uk.EUMember(time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, uk.EUMember(time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)))
+
+	croatia, _ := vat.LookupCountry("HR")
+	assert.False(t, croatia.EUMember(time.Date(2013, time.June, 30, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, croatia.EUMember(time.Now()))
+
+	swiss, _ := vat.LookupCountry("CH")
+	assert.False(t, swiss.EUMember(time.Now()))
+}
@@ -0,0 +1,303 @@
+package vat
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CountrySpec declares the rules and metadata of a VAT prefix, see RegisterCountry.
+// The built-in countries are declared in countries.json.
+type CountrySpec struct {
+	// Code is the VAT prefix, two uppercase letters.
+	Code string `json:"code"`
+	// ISOCode is the ISO 3166 alpha-2 code of the country, if it differs from Code.
+	// It is accepted as an alias of Code by Parse unless it is a VAT prefix itself.
+	ISOCode string `json:"isoCode,omitempty"`
+	// Name is the English name of the country.
+	Name string `json:"name"`
+	// LocalName is the name of the country in its official language(s).
+	LocalName string `json:"localName,omitempty"`
+	// EUJoined is the day the country joined the EU, zero if it never did.
+	EUJoined time.Time `json:"euJoined,omitzero"`
+	// EULeft is the first day the country was no longer an EU member, zero if it still is or never was.
+	EULeft time.Time `json:"euLeft,omitzero"`
+	// Lengths are the allowed lengths of the number, without the prefix.
+	Lengths []LengthRange `json:"lengths,omitempty"`
+	// Characters are the characters allowed in the number, as the contents of a regexp character class, e.g. 0-9A-Z.
+	Characters string `json:"characters,omitempty"`
+	// Pattern is a regexp the whole number, without the prefix, must match.
+	// It replaces Lengths and Characters for layouts they can't describe.
+	Pattern string `json:"pattern,omitempty"`
+	// Checksum names the check digit algorithm of the number, empty if it has none, as for the built-in
+	// Liechtenstein, San Marino, Andorra, Albania, Armenia and Georgia, whose numbers are only format checked.
+	// Besides the algorithms of the built-in countries, named after their lowercase prefix,
+	// luhn, iso7064-mod11-10 and iso7064-mod97-10 are available.
+	Checksum string `json:"checksum,omitempty"`
+	// Format describes the expected format for humans, see Country.
+	Format string `json:"format,omitempty"`
+	// Layouts are the templates IDNumber.Format tries in order for the human and official styles.
+	// Every # stands for the next character of the compact form, prefix included, anything else for itself;
+	// the first template with as many # as the compact form has characters is used.
+	// To round-trip through Parse, templates may only add spaces and characters the pattern accepts.
+	Layouts map[FormatStyle][]string `json:"layouts,omitempty"`
+	// Examples are valid numbers, including the prefix. RegisterCountry checks they pass the rules.
+	Examples []string `json:"examples,omitempty"`
+	// Providers are the services able to verify numbers of the country, the default one first.
+	Providers []Provider `json:"providers,omitempty"`
+}
+
+// LengthRange is an inclusive range of lengths.
+type LengthRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// countrySpec is a CountrySpec with its rules compiled.
+type countrySpec struct {
+	CountrySpec
+
+	pattern     *regexp.Regexp
+	prog        partialProg
+	matcher     *matcher
+	checkDigits func(number string) bool
+}
+
+// registry holds the countries Parse knows about. Specs are never modified once registered,
+// so they can be used after the lock is released.
+type registry struct {
+	mu      sync.RWMutex
+	specs   map[string]*countrySpec
+	aliases map[string]string
+}
+
+// The specs are also exported for frontends, run go generate after changing them.
+//
+//go:generate go run ./cmd/vatgen -schema generated/vat.schema.json -openapi generated/openapi.json -ts generated/vat.ts -vectors generated/vectors.json -check-digits testdata/check_digits.json
+//go:embed countries.json
+var countriesJSON []byte
+
+//nolint:gochecknoglobals // The registry is shared by Parse and RegisterCountry, guarded by its mutex.
+var countryRegistry = mustLoadRegistry(countriesJSON)
+
+func mustLoadRegistry(data []byte) *registry {
+	var specs []CountrySpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		panic(fmt.Sprintf("vat: invalid countries.json: %v", err))
+	}
+
+	r := &registry{
+		specs:   make(map[string]*countrySpec, len(specs)),
+		aliases: make(map[string]string),
+	}
+	for _, spec := range specs {
+		if err := r.register(spec); err != nil {
+			panic(fmt.Sprintf("vat: invalid countries.json: %v", err))
+		}
+	}
+
+	return r
+}
+
+// RegisterCountry adds a VAT prefix to the ones Parse accepts, or replaces the rules of an existing one.
+// Use it to ship corrected rules before a new release, or to accept internal and test prefixes.
+// It is safe to call concurrently with Parse and the other functions of the package.
+// Errors match ErrInvalidCountrySpec.
+func RegisterCountry(spec CountrySpec) error {
+	return countryRegistry.register(spec)
+}
+
+// UnregisterCountry removes a VAT prefix from the ones Parse accepts, e.g. a test prefix added with RegisterCountry.
+// Built-in prefixes can be removed too; register their spec again, as returned by CountrySpecs, to restore them.
+// It reports whether the prefix was registered.
+func UnregisterCountry(countryCode string) bool {
+	return countryRegistry.unregister(countryCode)
+}
+
+// CountrySpecs returns the specs of every registered VAT prefix, sorted by Code.
+// It is meant for tools generating validation rules for other languages, see cmd/vatgen.
+func CountrySpecs() []CountrySpec {
+	compiled := allSpecs()
+	specs := make([]CountrySpec, 0, len(compiled))
+	for _, spec := range compiled {
+		specs = append(specs, spec.clone())
+	}
+
+	slices.SortFunc(specs, func(a, b CountrySpec) int { return strings.Compare(a.Code, b.Code) })
+
+	return specs
+}
+
+// clone returns a copy of s that shares no slices or maps with it.
+func (s CountrySpec) clone() CountrySpec {
+	s.Lengths = slices.Clone(s.Lengths)
+	s.Examples = slices.Clone(s.Examples)
+	s.Providers = slices.Clone(s.Providers)
+	if s.Layouts != nil {
+		layouts := make(map[FormatStyle][]string, len(s.Layouts))
+		for style, templates := range s.Layouts {
+			layouts[style] = slices.Clone(templates)
+		}
+
+		s.Layouts = layouts
+	}
+
+	return s
+}
+
+// NumberRegexp returns the anchored regexp the numbers of s must match, without the prefix.
+// It is built from Pattern, or from Lengths and Characters. Errors match ErrInvalidCountrySpec.
+func (s CountrySpec) NumberRegexp() (string, error) {
+	return specPattern(s)
+}
+
+func (r *registry) register(spec CountrySpec) error {
+	compiled, err := compileSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.specs[spec.Code] = compiled
+	r.updateAliases()
+
+	return nil
+}
+
+func (r *registry) unregister(countryCode string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.specs[countryCode]; !ok {
+		return false
+	}
+
+	delete(r.specs, countryCode)
+	r.updateAliases()
+
+	return true
+}
+
+// updateAliases maps the ISO codes that are not VAT prefixes themselves to their prefix.
+// The caller must hold the write lock.
+func (r *registry) updateAliases() {
+	r.aliases = make(map[string]string)
+	for code, s := range r.specs {
+		if s.ISOCode != "" && s.ISOCode != code {
+			if _, isPrefix := r.specs[s.ISOCode]; !isPrefix {
+				r.aliases[s.ISOCode] = code
+			}
+		}
+	}
+}
+
+func compileSpec(spec CountrySpec) (*countrySpec, error) {
+	if !isCountryCode(spec.Code) {
+		return nil, fmt.Errorf("%w: code %q is not two uppercase letters", ErrInvalidCountrySpec, spec.Code)
+	}
+
+	if spec.ISOCode != "" && !isCountryCode(spec.ISOCode) {
+		return nil, fmt.Errorf("%w: %s: ISO code %q is not two uppercase letters",
+			ErrInvalidCountrySpec, spec.Code, spec.ISOCode)
+	}
+
+	expr, err := specPattern(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCountrySpec, spec.Code, err)
+	}
+
+	prog, err := compilePartial(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCountrySpec, spec.Code, err)
+	}
+
+	compiled := &countrySpec{CountrySpec: spec, pattern: pattern, prog: prog, matcher: newMatcher(pattern, prog)}
+	if spec.Checksum != "" {
+		var ok bool
+		compiled.checkDigits, ok = checksums[spec.Checksum]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: unknown checksum %q", ErrInvalidCountrySpec, spec.Code, spec.Checksum)
+		}
+	}
+
+	for _, example := range spec.Examples {
+		number, found := strings.CutPrefix(example, spec.Code)
+		if !found || !compiled.valid(number) {
+			return nil, fmt.Errorf("%w: %s: example %s does not pass the rules", ErrInvalidCountrySpec, spec.Code, example)
+		}
+	}
+
+	return compiled, nil
+}
+
+// specPattern returns the anchored regexp matching the numbers of spec.
+func specPattern(spec CountrySpec) (string, error) {
+	if spec.Pattern != "" {
+		return "^(?:" + spec.Pattern + ")$", nil
+	}
+
+	if len(spec.Lengths) == 0 || spec.Characters == "" {
+		return "", fmt.Errorf("%w: %s: either a pattern or lengths and characters are required",
+			ErrInvalidCountrySpec, spec.Code)
+	}
+
+	alternatives := make([]string, 0, len(spec.Lengths))
+	for _, length := range spec.Lengths {
+		if length.Min < 1 || length.Max < length.Min {
+			return "", fmt.Errorf("%w: %s: invalid length range %d-%d",
+				ErrInvalidCountrySpec, spec.Code, length.Min, length.Max)
+		}
+
+		alternatives = append(alternatives, fmt.Sprintf("[%s]{%d,%d}", spec.Characters, length.Min, length.Max))
+	}
+
+	return "^(?:" + strings.Join(alternatives, "|") + ")$", nil
+}
+
+func isCountryCode(s string) bool {
+	return len(s) == 2 && 'A' <= s[0] && s[0] <= 'Z' && 'A' <= s[1] && s[1] <= 'Z'
+}
+
+// valid reports whether number, without the prefix, matches the pattern and check digits of the spec.
+func (s *countrySpec) valid(number string) bool {
+	return s.matcher.match(number) && (s.checkDigits == nil || s.checkDigits(number))
+}
+
+// lookupSpec returns the spec of a VAT prefix, or of the prefix it is an alias of.
+func lookupSpec(countryCode string) (*countrySpec, bool) {
+	countryRegistry.mu.RLock()
+	defer countryRegistry.mu.RUnlock()
+
+	if alias, ok := countryRegistry.aliases[countryCode]; ok {
+		countryCode = alias
+	}
+
+	spec, ok := countryRegistry.specs[countryCode]
+
+	return spec, ok
+}
+
+// allSpecs returns the specs of every registered prefix, in no particular order.
+func allSpecs() []*countrySpec {
+	countryRegistry.mu.RLock()
+	defer countryRegistry.mu.RUnlock()
+
+	specs := make([]*countrySpec, 0, len(countryRegistry.specs))
+	for _, spec := range countryRegistry.specs {
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
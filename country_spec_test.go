@@ -0,0 +1,173 @@
+package vat_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+// registerTestCountry registers spec for the duration of the test, restoring the previous spec of its code,
+// if any, when the test ends, so that other tests don't see it.
+func registerTestCountry(t *testing.T, spec vat.CountrySpec) error {
+	t.Helper()
+
+	var previous *vat.CountrySpec
+	for _, s := range vat.CountrySpecs() {
+		if s.Code == spec.Code {
+			previous = &s
+		}
+	}
+
+	t.Cleanup(func() {
+		if previous != nil {
+			require.NoError(t, vat.RegisterCountry(*previous))
+
+			return
+		}
+
+		vat.UnregisterCountry(spec.Code)
+	})
+
+	return vat.RegisterCountry(spec)
+}
+
+func TestRegisterCountry(t *testing.T) {
+	err := registerTestCountry(t, vat.CountrySpec{
+		Code:       "QT",
+		Name:       "Test country",
+		LocalName:  "Test country",
+		Lengths:    []vat.LengthRange{{Min: 6, Max: 8}},
+		Characters: "0-9",
+		Checksum:   "luhn",
+		Format:     "QT999999 to QT99999999",
+		Examples:   []string{"QT1234566"},
+	})
+	require.NoError(t, err)
+
+	id, err := vat.Parse("QT1234566")
+	require.NoError(t, err)
+	assert.Equal(t, vat.IDNumber{CountryCode: "QT", Number: "1234566"}, id)
+
+	_, err = vat.Parse("QT1234567")
+	require.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+
+	_, err = vat.Parse("QT12345")
+	var parseErr *vat.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, vat.ReasonTooShort, parseErr.Reason)
+	assert.Equal(t, "QT999999 to QT99999999", parseErr.ExpectedFormat)
+
+	country, ok := vat.LookupCountry("QT")
+	require.True(t, ok)
+	assert.Equal(t, "Test country", country.Name)
+	assert.Empty(t, vat.ProvidersFor("QT"))
+}
+
+func TestUnregisterCountry(t *testing.T) {
+	require.NoError(t, registerTestCountry(t, vat.CountrySpec{
+		Code:       "QU",
+		Name:       "Unregister test country",
+		LocalName:  "Unregister test country",
+		Lengths:    []vat.LengthRange{{Min: 8, Max: 8}},
+		Characters: "0-9",
+		Format:     "QU99999999",
+		Examples:   []string{"QU12345678"},
+	}))
+	assert.True(t, vat.UnregisterCountry("QU"))
+	assert.False(t, vat.UnregisterCountry("QU"))
+
+	_, err := vat.Parse("QU12345678")
+	require.ErrorIs(t, err, vat.ErrInvalidCountryCode)
+
+	// Removing a prefix with an ISO code alias removes the alias too.
+	var greece vat.CountrySpec
+	for _, s := range vat.CountrySpecs() {
+		if s.Code == "EL" {
+			greece = s
+		}
+	}
+	t.Cleanup(func() { require.NoError(t, vat.RegisterCountry(greece)) })
+
+	assert.True(t, vat.UnregisterCountry("EL"))
+	_, ok := vat.LookupCountry("GR")
+	assert.False(t, ok)
+}
+
+func TestRegisterCountry_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec vat.CountrySpec
+	}{
+		{
+			name: "lowercase code",
+			spec: vat.CountrySpec{Code: "qt", Pattern: "[0-9]{8}"},
+		},
+		{
+			name: "no rules",
+			spec: vat.CountrySpec{Code: "QT"},
+		},
+		{
+			name: "lengths without characters",
+			spec: vat.CountrySpec{Code: "QT", Lengths: []vat.LengthRange{{Min: 8, Max: 8}}},
+		},
+		{
+			name: "empty length range",
+			spec: vat.CountrySpec{Code: "QT", Lengths: []vat.LengthRange{{Min: 8, Max: 6}}, Characters: "0-9"},
+		},
+		{
+			name: "invalid pattern",
+			spec: vat.CountrySpec{Code: "QT", Pattern: "[0-9"},
+		},
+		{
+			name: "unknown checksum",
+			spec: vat.CountrySpec{Code: "QT", Pattern: "[0-9]{8}", Checksum: "crc32"},
+		},
+		{
+			name: "invalid example",
+			spec: vat.CountrySpec{Code: "QT", Pattern: "[0-9]{8}", Checksum: "luhn", Examples: []string{"QT12345678"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := vat.RegisterCountry(tt.spec)
+			require.ErrorIs(t, err, vat.ErrInvalidCountrySpec)
+		})
+	}
+}
+
+func TestRegisterCountry_Concurrent(t *testing.T) {
+	spec := vat.CountrySpec{
+		Code:       "QC",
+		Name:       "Concurrent test country",
+		LocalName:  "Concurrent test country",
+		Lengths:    []vat.LengthRange{{Min: 8, Max: 8}},
+		Characters: "0-9",
+		Format:     "QC99999999",
+		Examples:   []string{"QC12345678"},
+	}
+	t.Cleanup(func() { vat.UnregisterCountry(spec.Code) })
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				assert.NoError(t, vat.RegisterCountry(spec))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, err := vat.Parse("DE136695976")
+				assert.NoError(t, err)
+				_ = vat.Countries()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,168 @@
+package vat
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalText implements encoding.TextMarshaler, and thereby JSON encoding, with the canonical form of the number.
+// The zero IDNumber is rejected, as it would encode to an empty string that UnmarshalText rejects;
+// use NullIDNumber for numbers that may be missing.
+func (id IDNumber) MarshalText() ([]byte, error) {
+	if id.isZero() {
+		return nil, errZeroIDNumber
+	}
+
+	return []byte(id.Canonical().String()), nil
+}
+
+// errZeroIDNumber is returned when encoding the zero IDNumber.
+//
+//nolint:gochecknoglobals // This is a constant error.
+var errZeroIDNumber = fmt.Errorf("%w: the zero IDNumber can't be encoded, use NullIDNumber for missing numbers",
+	ErrInvalidFormat)
+
+func (id IDNumber) isZero() bool {
+	return id.CountryCode == "" && id.Number == ""
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, and thereby JSON decoding, using Parse.
+// Errors are of type *ParseError.
+func (id *IDNumber) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+
+	return nil
+}
+
+// Value implements driver.Valuer, storing the canonical form of the number in a text column.
+// Like MarshalText, it rejects the zero IDNumber.
+func (id IDNumber) Value() (driver.Value, error) {
+	if id.isZero() {
+		return nil, errZeroIDNumber
+	}
+
+	return id.Canonical().String(), nil
+}
+
+// Scan implements sql.Scanner for text columns, using Parse. Use NullIDNumber for nullable columns.
+func (id *IDNumber) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return id.UnmarshalText([]byte(src))
+	case []byte:
+		return id.UnmarshalText(src)
+	default:
+		return fmt.Errorf("vat: cannot scan %T into IDNumber", src)
+	}
+}
+
+// Set implements flag.Value, using Parse.
+func (id *IDNumber) Set(s string) error {
+	return id.UnmarshalText([]byte(s))
+}
+
+// NullIDNumber is an IDNumber that may be missing, like sql.NullString.
+// It encodes as null in JSON and SQL, and as an empty string in text, when Valid is false.
+//
+//nolint:recvcheck // Decoding methods need a pointer receiver, the others work on copies like sql.NullString.
+type NullIDNumber struct {
+	IDNumber IDNumber
+	Valid    bool
+}
+
+// String returns the number, or an empty string if it is missing.
+func (n NullIDNumber) String() string {
+	if !n.Valid {
+		return ""
+	}
+
+	return n.IDNumber.String()
+}
+
+// MarshalText implements encoding.TextMarshaler, with an empty text for missing numbers.
+func (n NullIDNumber) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+
+	return n.IDNumber.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, treating an empty text as a missing number.
+func (n *NullIDNumber) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullIDNumber{}
+
+		return nil
+	}
+
+	if err := n.IDNumber.UnmarshalText(text); err != nil {
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, with null for missing numbers.
+func (n NullIDNumber) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.IDNumber)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, treating null and an empty string as a missing number.
+func (n *NullIDNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullIDNumber{}
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return n.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer, with NULL for missing numbers.
+func (n NullIDNumber) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil //nolint:nilnil // NULL is a valid driver.Value.
+	}
+
+	return n.IDNumber.Value()
+}
+
+// Scan implements sql.Scanner, treating NULL as a missing number.
+func (n *NullIDNumber) Scan(src any) error {
+	if src == nil {
+		*n = NullIDNumber{}
+
+		return nil
+	}
+
+	if err := n.IDNumber.Scan(src); err != nil {
+		return err
+	}
+
+	n.Valid = true
+
+	return nil
+}
+
+// Set implements flag.Value, treating an empty value as a missing number.
+func (n *NullIDNumber) Set(s string) error {
+	return n.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,97 @@
+package vat_test
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_JSON(t *testing.T) {
+	type customer struct {
+		VATNumber vat.IDNumber     `json:"vatNumber"`
+		Parent    vat.NullIDNumber `json:"parent"`
+	}
+
+	data, err := json.Marshal(customer{VATNumber: vat.MustParse("GR094259216")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"vatNumber":"EL094259216","parent":null}`, string(data))
+
+	var got customer
+	err = json.Unmarshal([]byte(`{"vatNumber":"NL 822010690B01","parent":"DE136695976"}`), &got)
+	require.NoError(t, err)
+	assert.Equal(t, customer{
+		VATNumber: vat.IDNumber{CountryCode: "NL", Number: "822010690B01"},
+		Parent:    vat.NullIDNumber{IDNumber: vat.IDNumber{CountryCode: "DE", Number: "136695976"}, Valid: true},
+	}, got)
+
+	err = json.Unmarshal([]byte(`{"vatNumber":"DE136695977"}`), &got)
+	var parseErr *vat.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, vat.ReasonCheckDigits, parseErr.Reason)
+
+	got = customer{}
+	err = json.Unmarshal([]byte(`{"vatNumber":"DE136695976","parent":""}`), &got)
+	require.NoError(t, err)
+	assert.False(t, got.Parent.Valid)
+
+	// The zero IDNumber can't be decoded, so it isn't encoded either.
+	_, err = json.Marshal(customer{})
+	require.ErrorIs(t, err, vat.ErrInvalidFormat)
+	require.Error(t, json.Unmarshal([]byte(`{"vatNumber":""}`), &got))
+}
+
+func TestIDNumber_SQL(t *testing.T) {
+	value, err := vat.MustParse("AT13585627").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "ATU13585627", value)
+
+	var id vat.IDNumber
+	require.NoError(t, id.Scan([]byte("DE136695976")))
+	assert.Equal(t, vat.IDNumber{CountryCode: "DE", Number: "136695976"}, id)
+	require.NoError(t, id.Scan("NL822010690B01"))
+	assert.Equal(t, vat.IDNumber{CountryCode: "NL", Number: "822010690B01"}, id)
+	require.ErrorIs(t, id.Scan("DE136695977"), vat.ErrInvalidCheckDigits)
+	require.Error(t, id.Scan(nil))
+	require.Error(t, id.Scan(42))
+
+	_, err = vat.IDNumber{}.Value()
+	require.ErrorIs(t, err, vat.ErrInvalidFormat)
+	require.Error(t, id.Scan(""))
+
+	var null vat.NullIDNumber
+	require.NoError(t, null.Scan(nil))
+	assert.False(t, null.Valid)
+	value, err = null.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, null.Scan("DE136695976"))
+	assert.True(t, null.Valid)
+	value, err = null.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "DE136695976", value)
+}
+
+func TestIDNumber_Flag(t *testing.T) {
+	var (
+		id     vat.IDNumber
+		parent vat.NullIDNumber
+	)
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&id, "vat", "VAT number")
+	flags.Var(&parent, "parent", "VAT number of the parent company")
+
+	require.NoError(t, flags.Parse([]string{"-vat", "DE136695976"}))
+	assert.Equal(t, "DE136695976", id.String())
+	assert.False(t, parent.Valid)
+
+	require.Error(t, flags.Parse([]string{"-vat", "DE136695977"}))
+}
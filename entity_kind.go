@@ -0,0 +1,270 @@
+//nolint:mnd // Number structures are defined in terms of their lengths.
+package vat
+
+import "unicode/utf8"
+
+// EntityKind tells what kind of entity a VAT number was issued to, as far as the number itself shows it.
+type EntityKind string
+
+const (
+	// EntityKindUnknown means the number does not tell the kind of entity.
+	EntityKindUnknown EntityKind = "unknown"
+	// EntityKindCompany is a company or another private legal entity, like an association or a cooperative.
+	EntityKindCompany EntityKind = "company"
+	// EntityKindNaturalPerson is a sole trader or another natural person.
+	EntityKindNaturalPerson EntityKind = "natural_person"
+	// EntityKindPublicBody is a government department, local authority or another public body.
+	EntityKindPublicBody EntityKind = "public_body"
+	// EntityKindGroup is a VAT group, a branch or a permanent establishment of a foreign entity.
+	EntityKindGroup EntityKind = "group"
+)
+
+// entityKinds maps VAT prefixes to the function telling the kind of entity from a number
+// that already passed Parse. Countries whose numbers don't tell are left out.
+//
+//nolint:gochecknoglobals // This is a constant map of country codes to their entity kind rules.
+var entityKinds = map[string]func(number string) EntityKind{
+	"BG": entityKindBG,
+	"BY": entityKindBY,
+	"CZ": entityKindCZ,
+	"ES": entityKindES,
+	"FR": entityKindFR,
+	"GB": entityKindGB,
+	"GE": entityKindGE,
+	"IE": entityKindIE,
+	"KZ": entityKindKZ,
+	"LT": entityKindLT,
+	"LV": entityKindLV,
+	"MX": entityKindMX,
+	"NL": entityKindNL,
+	"PT": entityKindPT,
+	"RU": entityKindRU,
+	"SE": entityKindSE,
+	"UA": entityKindUA,
+	"XI": entityKindGB,
+}
+
+// EntityKind returns the kind of entity the number was issued to, derived from its structure,
+// or EntityKindUnknown if the number does not tell. The number is expected to come from Parse.
+//
+// The kind only depends on the number Parse accepted, so it is computed here rather than stored by Parse:
+// the result is the same, IDNumber stays comparable with ==, and numbers decoded from JSON or a database,
+// or built as literals, get their kind too.
+func (id IDNumber) EntityKind() EntityKind {
+	id = id.Canonical()
+
+	kind, ok := entityKinds[id.CountryCode]
+	if !ok || id.Number == "" {
+		return EntityKindUnknown
+	}
+
+	return kind(id.Number)
+}
+
+// entityKindBG tells EIK numbers of legal entities from EGN numbers of natural persons.
+func entityKindBG(number string) EntityKind {
+	if len(number) == 9 {
+		return EntityKindCompany
+	}
+
+	return EntityKindNaturalPerson
+}
+
+// entityKindBY tells the UNP of sole traders, whose second character is a letter, from those of legal entities.
+func entityKindBY(number string) EntityKind {
+	if number[1] >= 'A' && number[1] <= 'Z' {
+		return EntityKindNaturalPerson
+	}
+
+	return EntityKindCompany
+}
+
+// entityKindCZ tells IČO numbers of legal entities from birth numbers of natural persons.
+func entityKindCZ(number string) EntityKind {
+	if len(number) == 8 {
+		return EntityKindCompany
+	}
+
+	return EntityKindNaturalPerson
+}
+
+// entityKindES uses the first letter of CIF numbers, which tells the legal form.
+// NIF numbers of Spaniards start with a digit, or with K, L or M, and NIE numbers of foreigners with X, Y or Z.
+func entityKindES(number string) EntityKind {
+	switch number[0] {
+	case 'P', 'Q', 'S':
+		// Local authorities, public bodies and state administration
+		return EntityKindPublicBody
+	case 'W':
+		// Permanent establishments of non-resident entities
+		return EntityKindGroup
+	case 'K', 'L', 'M', 'X', 'Y', 'Z', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return EntityKindNaturalPerson
+	default:
+		return EntityKindCompany
+	}
+}
+
+// entityKindFR tells numbers with an alphanumeric key, issued to businesses without a SIREN, like foreign companies,
+// and SIREN numbers of public bodies, which start with 1 or 2. Other SIREN numbers belong to companies
+// and sole traders alike.
+func entityKindFR(number string) EntityKind {
+	if !isDigits(number[:2]) {
+		return EntityKindCompany
+	}
+
+	if number[2] == '1' || number[2] == '2' {
+		return EntityKindPublicBody
+	}
+
+	return EntityKindUnknown
+}
+
+func entityKindGB(number string) EntityKind {
+	switch (IDNumber{CountryCode: "GB", Number: number}).UKKind() {
+	case UKKindGovernmentDepartment, UKKindHealthAuthority:
+		return EntityKindPublicBody
+	case UKKindBranchTrader:
+		return EntityKindGroup
+	case UKKindStandard, UKKindNone:
+		return EntityKindUnknown
+	default:
+		return EntityKindUnknown
+	}
+}
+
+// entityKindGE tells the 9 digit identification numbers of legal entities from the 11 digit personal numbers
+// of individual entrepreneurs.
+func entityKindGE(number string) EntityKind {
+	if len(number) == 9 {
+		return EntityKindCompany
+	}
+
+	return EntityKindNaturalPerson
+}
+
+// entityKindIE tells old style numbers like 1X23456T, issued to companies, and new style numbers
+// ending in H, issued to companies since 2013, from those based on the PPS number of a natural person.
+func entityKindIE(number string) EntityKind {
+	switch {
+	case number[1] < '0' || number[1] > '9':
+		return EntityKindCompany
+	case len(number) == 9 && number[8] == 'H':
+		return EntityKindCompany
+	case len(number) == 9:
+		return EntityKindNaturalPerson
+	default:
+		return EntityKindUnknown
+	}
+}
+
+// entityKindKZ uses the fifth digit, which is the first digit of the birth day in IIN numbers of natural persons
+// and tells the kind of legal entity in BIN numbers, followed by a digit telling head offices from branches.
+func entityKindKZ(number string) EntityKind {
+	switch number[4] {
+	case '0', '1', '2', '3':
+		return EntityKindNaturalPerson
+	case '4', '5':
+		// Resident and non-resident legal entities
+		if number[5] == '1' || number[5] == '2' {
+			// Branches and representative offices
+			return EntityKindGroup
+		}
+
+		return EntityKindCompany
+	case '6':
+		// Individual entrepreneurs running a joint business
+		return EntityKindNaturalPerson
+	default:
+		return EntityKindUnknown
+	}
+}
+
+// entityKindLT tells the 9 digit numbers of legal entities. 12 digit numbers are issued to natural persons
+// and to temporarily registered taxpayers alike.
+func entityKindLT(number string) EntityKind {
+	if len(number) == 9 {
+		return EntityKindCompany
+	}
+
+	return EntityKindUnknown
+}
+
+// entityKindLV tells registration numbers of legal entities, which start with a digit above 3,
+// from personal codes, which start with the birth date.
+func entityKindLV(number string) EntityKind {
+	if number[0] > '3' {
+		return EntityKindCompany
+	}
+
+	return EntityKindNaturalPerson
+}
+
+// entityKindMX tells the 12 character RFC of companies from the 13 character RFC of natural persons.
+// The generic numbers for the general public and for foreign customers tell neither.
+func entityKindMX(number string) EntityKind {
+	switch {
+	case number == mxGenericRFC || number == mxForeignRFC:
+		return EntityKindUnknown
+	case utf8.RuneCountInString(number) == 12:
+		return EntityKindCompany
+	default:
+		return EntityKindNaturalPerson
+	}
+}
+
+// entityKindNL tells the numbers issued to sole proprietors since 2020, which only pass the Mod 97 check.
+// Older numbers are based on the RSIN of companies or the BSN of sole proprietors, which look alike.
+func entityKindNL(number string) EntityKind {
+	if (weightedSum(number, []int{9, 8, 7, 6, 5, 4, 3, 2, -1})%11+11)%11 != 0 {
+		return EntityKindNaturalPerson
+	}
+
+	return EntityKindUnknown
+}
+
+// entityKindPT uses the first digit of the NIF, which tells the kind of taxpayer.
+func entityKindPT(number string) EntityKind {
+	switch number[0] {
+	case '1', '2', '3', '4', '8':
+		// Residents, non-residents and sole traders
+		return EntityKindNaturalPerson
+	case '6':
+		return EntityKindPublicBody
+	default:
+		return EntityKindCompany
+	}
+}
+
+// entityKindRU tells the 10 digit INN of legal entities, optionally followed by the KPP,
+// from the 12 digit INN of natural persons.
+func entityKindRU(number string) EntityKind {
+	if len(number) == 12 {
+		return EntityKindNaturalPerson
+	}
+
+	return EntityKindCompany
+}
+
+// entityKindSE tells organisation numbers, whose third digit is at least 2, from the personal identity numbers
+// of sole traders, which start with the birth date. Organisation numbers starting with 2 belong to public bodies.
+func entityKindSE(number string) EntityKind {
+	switch {
+	case number[2] < '2':
+		return EntityKindNaturalPerson
+	case number[0] == '2':
+		return EntityKindPublicBody
+	default:
+		return EntityKindCompany
+	}
+}
+
+// entityKindUA tells the EDRPOU codes of legal entities, and the VAT IPN derived from them,
+// from the RNTRC numbers of natural persons.
+func entityKindUA(number string) EntityKind {
+	if len(number) == 10 {
+		return EntityKindNaturalPerson
+	}
+
+	return EntityKindCompany
+}
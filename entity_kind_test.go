@@ -0,0 +1,72 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_EntityKind(t *testing.T) {
+	tests := []struct {
+		input string
+		want  vat.EntityKind
+	}{
+		{input: "ESA13585625", want: vat.EntityKindCompany},
+		{input: "ESB58378431", want: vat.EntityKindCompany},
+		{input: "ESQ2826000H", want: vat.EntityKindPublicBody},
+		{input: "ES12345678Z", want: vat.EntityKindNaturalPerson},
+		{input: "ESX1234567L", want: vat.EntityKindNaturalPerson},
+		{input: "IE8Z49289F", want: vat.EntityKindCompany},
+		{input: "IE3628739OH", want: vat.EntityKindCompany},
+		{input: "IE3628739UA", want: vat.EntityKindNaturalPerson},
+		{input: "IE6433435F", want: vat.EntityKindUnknown},
+		{input: "FR38200054781", want: vat.EntityKindPublicBody},
+		{input: "FR40303265045", want: vat.EntityKindUnknown},
+		{input: "NL100000024B01", want: vat.EntityKindNaturalPerson},
+		{input: "NL822010690B01", want: vat.EntityKindUnknown},
+		{input: "SE556188840401", want: vat.EntityKindCompany},
+		{input: "SE202100548901", want: vat.EntityKindPublicBody},
+		{input: "SE640823323401", want: vat.EntityKindNaturalPerson},
+		{input: "CZ25123891", want: vat.EntityKindCompany},
+		{input: "CZ7103192745", want: vat.EntityKindNaturalPerson},
+		{input: "PT501964843", want: vat.EntityKindCompany},
+		{input: "PT600000001", want: vat.EntityKindPublicBody},
+		{input: "PT123456789", want: vat.EntityKindNaturalPerson},
+		{input: "BG175074752", want: vat.EntityKindCompany},
+		{input: "LV40003521600", want: vat.EntityKindCompany},
+		{input: "LT119511515", want: vat.EntityKindCompany},
+		{input: "RU7707083893", want: vat.EntityKindCompany},
+		{input: "RU7707083893/773601001", want: vat.EntityKindCompany},
+		{input: "RU500100732259", want: vat.EntityKindNaturalPerson},
+		{input: "UA32855961", want: vat.EntityKindCompany},
+		{input: "UA1759013776", want: vat.EntityKindNaturalPerson},
+		{input: "KZ971240001315", want: vat.EntityKindCompany},
+		{input: "KZ971241001310", want: vat.EntityKindGroup},
+		{input: "KZ900101300126", want: vat.EntityKindNaturalPerson},
+		{input: "GE204470740", want: vat.EntityKindCompany},
+		{input: "BY200988541", want: vat.EntityKindCompany},
+		{input: "BYMA1953684", want: vat.EntityKindNaturalPerson},
+		{input: "MXSAT970701NN3", want: vat.EntityKindCompany},
+		{input: "MXGODE561231GR8", want: vat.EntityKindNaturalPerson},
+		{input: "MXXAXX010101000", want: vat.EntityKindUnknown},
+		{input: "GE01024085800", want: vat.EntityKindNaturalPerson},
+		{input: "GBGD001", want: vat.EntityKindPublicBody},
+		{input: "XIHA500", want: vat.EntityKindPublicBody},
+		{input: "GB980780684001", want: vat.EntityKindGroup},
+		{input: "GB980780684", want: vat.EntityKindUnknown},
+		{input: "DE136695976", want: vat.EntityKindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, vat.MustParse(tt.input).EntityKind())
+		})
+	}
+}
+
+func TestIDNumber_EntityKind_Literal(t *testing.T) {
+	id := vat.IDNumber{CountryCode: "ES", Number: "Q2826000H"}
+	assert.Equal(t, vat.MustParse("ESQ2826000H"), id)
+	assert.Equal(t, vat.EntityKindPublicBody, id.EntityKind())
+}
@@ -1,10 +1,24 @@
 package vat
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrInvalidFormat      = errors.New("invalid vat number format")
 	ErrNotFound           = errors.New("vat number not found")
 	ErrServiceUnavailable = errors.New("validation service unavailable")
 	ErrInvalidCountryCode = errors.New("invalid country code")
+	// ErrUnsupportedCountry is returned by Validator for countries no registry can verify numbers of,
+	// unlike ErrNoValidationClient, which means a registry exists but no client was routed to it.
+	ErrUnsupportedCountry = errors.New("no registry can verify numbers of this country")
+	ErrNoValidationClient = errors.New("online check required but no validation client configured")
+	// ErrInvalidCheckDigits is returned when a number has the right shape but its check digits don't match.
+	// It wraps ErrInvalidFormat.
+	ErrInvalidCheckDigits = fmt.Errorf("%w: check digits mismatch", ErrInvalidFormat)
+	// ErrInvalidCountrySpec is returned by RegisterCountry for specs that can't be compiled into rules.
+	ErrInvalidCountrySpec = errors.New("invalid country spec")
+	// ErrInvalidPseudonymKey is returned by Pseudonymize for keys that are missing an ID or have a short secret.
+	ErrInvalidPseudonymKey = errors.New("invalid pseudonym key")
 )
@@ -22,7 +22,8 @@ func main() {
 		return
 	}
 
-	logger.Info("Parsed VAT number", "country_code", vatIN.CountryCode, "number", vatIN.Number)
+	// IDNumber implements slog.LogValuer, so only a masked number is logged
+	logger.Info("Parsed VAT number", "vat_number", vatIN)
 
 	vat.MustParse("NL822010690B01")
 
@@ -53,7 +54,7 @@ func main() {
 	)
 
 	vats := []string{
-		"GB146295999727",
+		"GB980780684",
 		"NL822010690B01",
 		"NL822010690B02",
 		"GB123456789",
@@ -62,13 +63,14 @@ func main() {
 	}
 
 	for _, vatNumber := range vats {
-		err = validator.Validate(context.Background(), vatNumber)
+		var result vat.ValidationResult
+		result, err = validator.ValidateDetailed(context.Background(), vatNumber)
 		if err != nil {
-			logger.Error("VAT number is invalid", "error", err, "vat_number", vatNumber)
+			logger.Error("VAT number is invalid", "error", err, "vat_number", vat.MaskString(vatNumber))
 
 			continue
 		}
 
-		logger.Info("VAT number is valid", "vat_number", vatNumber)
+		logger.Info("VAT number is valid", "result", result)
 	}
 }
@@ -0,0 +1,299 @@
+package vat
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Match is a VAT number found in a text by FindAll.
+type Match struct {
+	// ID is the parsed number.
+	ID IDNumber
+	// Start and End are the byte offsets of the number in the text, End being exclusive.
+	Start int
+	End   int
+	// Confidence is between 0 and 1: numbers introduced by a label like "VAT No." or "USt-IdNr." score higher
+	// than bare ones, and numbers whose country was inferred from the label lower than those with a prefix.
+	Confidence float64
+}
+
+const (
+	confidenceLabelled       = 0.95
+	confidencePrefixed       = 0.8
+	confidenceFromLabel      = 0.7
+	confidenceAmbiguousLabel = 0.5
+	// confidenceNoCheckDigits is subtracted for countries whose numbers have no check digits.
+	confidenceNoCheckDigits = 0.3
+
+	// maxLabelGap is the number of separators allowed between a label and the number it introduces.
+	maxLabelGap = 4
+	// maxCandidateLength is the number of characters of the longest number looked at, the length of an IBAN.
+	maxCandidateLength = 34
+	ibanMinLength      = 15
+)
+
+// labelCountries maps the labels that tell the country of the number that follows them.
+//
+//nolint:gochecknoglobals // This is a constant map of labels to country codes.
+var labelCountries = map[string][]string{
+	"USTIDNR": {"DE"}, "USTID": {"DE"}, "USTIDNUMMER": {"DE"},
+	"UID": {"CH", "AT"}, "UIDNR": {"CH", "AT"}, "MWST": {"CH"}, "MWSTNR": {"CH"},
+	"TVA": {"FR", "BE", "LU"}, "NTVA": {"FR", "BE", "LU"}, "NOTVA": {"FR", "BE", "LU"},
+	"NUMEROTVA": {"FR", "BE", "LU"}, "TVAINTRACOM": {"FR"}, "TVAINTRACOMMUNAUTAIRE": {"FR"},
+	"BTW": {"NL", "BE"}, "BTWNR": {"NL", "BE"}, "BTWNUMMER": {"NL", "BE"}, "BTWID": {"NL", "BE"},
+	"IVA": {"IT", "ES", "PT"}, "PIVA": {"IT"}, "PARTITAIVA": {"IT"},
+	"NIF": {"ES", "PT"}, "NIFIVA": {"ES"}, "CIF": {"ES"}, "NIPC": {"PT"},
+	"MOMS": {"DK", "SE"}, "MOMSNR": {"DK", "SE"}, "MOMSREGNR": {"DK", "SE"},
+	"ALV": {"FI"}, "ALVNRO": {"FI"}, "PVM": {"LT"}, "PVMKODAS": {"LT"}, "KMKR": {"EE"},
+	"OIB": {"HR"}, "DDV": {"SI"}, "DPH": {"SK"}, "DIČ": {"CZ", "SK"}, "NIP": {"PL"}, "ΑΦΜ": {"EL"},
+	"ABN": {"AU"}, "MVA": {"NO"}, "MVANR": {"NO"}, "ORGNR": {"NO"}, "VSK": {"IS"}, "VSKNR": {"IS"}, "NRT": {"AD"},
+	"PIB": {"RS", "ME"}, "ПИБ": {"RS", "ME"}, "EDB": {"MK"}, "ЕДБ": {"MK"}, "NIPT": {"AL"}, "IDNO": {"MD"},
+	"EDRPOU": {"UA"}, "ЄДРПОУ": {"UA"}, "IPN": {"UA"}, "ІПН": {"UA"}, "UNP": {"BY"}, "УНП": {"BY"},
+	"BIN": {"KZ"}, "БИН": {"KZ"}, "INN": {"RU"}, "ИНН": {"RU"}, "INNKPP": {"RU"}, "ИННКПП": {"RU"},
+	"BN": {"CA"}, "GST": {"CA"}, "HST": {"CA"}, "GSTHST": {"CA"}, "TPS": {"CA"}, "TVH": {"CA"}, "TPSTVH": {"CA"},
+	"QST": {"CA"}, "TVQ": {"CA"}, "EIN": {"US"}, "FEIN": {"US"}, "RFC": {"MX"},
+}
+
+// FindAll returns the VAT numbers found in text, in order of appearance, e.g. in invoices and emails.
+//
+// Numbers may contain spaces, dots and dashes between their characters, and slashes where the pattern of their
+// country accepts them, like the Russian INN/KPP. They are found when they start with
+// an uppercase country prefix, or when a label like "P.IVA" or "NIF" tells their country. Every candidate must pass
+// Parse, including the check digits, and IBANs are skipped, which rules out most false positives.
+func FindAll(text string) []Match {
+	f := newFinder(text)
+
+	var matches []Match
+
+	labelEnd := -1
+	var labelCodes []string
+
+	for k := 0; k < len(f.runes); {
+		if k > 0 && isAlphanumeric(f.runes[k-1]) {
+			k++
+
+			continue
+		}
+
+		if label, n := f.labelAt(k); n > 0 {
+			labelEnd, labelCodes = k+n, labelCountries[label]
+			k += n
+
+			continue
+		}
+
+		labelled := labelEnd >= 0 && k-labelEnd <= maxLabelGap && f.onlySeparators(labelEnd, k)
+		if !labelled {
+			labelCodes = nil
+		}
+
+		if m, end, ok := f.matchAt(k, labelled, labelCodes); ok {
+			matches = append(matches, m)
+			labelEnd = -1
+			k = end
+
+			continue
+		}
+
+		k++
+	}
+
+	return matches
+}
+
+// finder holds the text uppercased, with the byte offset of every rune and of the end of the text.
+type finder struct {
+	original []rune
+	runes    []rune
+	offsets  []int
+}
+
+func newFinder(text string) *finder {
+	f := &finder{
+		original: make([]rune, 0, len(text)),
+		runes:    make([]rune, 0, len(text)),
+		offsets:  make([]int, 0, len(text)+1),
+	}
+	for i, r := range text {
+		r = foldWidth(r)
+		f.original = append(f.original, r)
+		f.runes = append(f.runes, unicode.ToUpper(r))
+		f.offsets = append(f.offsets, i)
+	}
+
+	f.offsets = append(f.offsets, len(text))
+
+	return f
+}
+
+func isAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isNumberSeparator reports whether r may appear between the characters of a number.
+func isNumberSeparator(r rune) bool {
+	return r == ' ' || r == '\u00a0' || r == '.' || r == '-'
+}
+
+func (f *finder) onlySeparators(from, to int) bool {
+	for _, r := range f.runes[from:to] {
+		if !isSeparator(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// labelAt returns the longest label known to labelCountries or labels at rune k, and the number of runes it takes.
+func (f *finder) labelAt(k int) (string, int) {
+	n := normalized{runes: f.runes[k:], offsets: f.offsets[k : len(f.offsets)-1]}
+
+	longest, found := 0, ""
+	for _, label := range labels {
+		if l := n.matchLabel(label); l > longest {
+			longest, found = l, label
+		}
+	}
+
+	for label := range labelCountries {
+		if l := n.matchLabel(label); l > longest {
+			longest, found = l, label
+		}
+	}
+
+	return found, longest
+}
+
+// candidate is a run of letters and digits with single separators between them.
+// Slashes are kept, as some patterns accept them, e.g. between the INN and KPP of Russian numbers.
+type candidate struct {
+	chars []rune
+	// ends holds the rune index following every character.
+	ends []int
+}
+
+func (f *finder) candidateAt(k int) candidate {
+	var c candidate
+	for i := k; i < len(f.runes) && len(c.chars) < maxCandidateLength; i++ {
+		switch {
+		case f.isNumberChar(i):
+			c.chars = append(c.chars, f.runes[i])
+			c.ends = append(c.ends, i+1)
+		case f.runes[i] == '/' && len(c.chars) > 0 && f.isNumberChar(i+1):
+			c.chars = append(c.chars, '/')
+			c.ends = append(c.ends, i+1)
+		case isNumberSeparator(f.runes[i]) && len(c.chars) > 0 && f.isNumberChar(i+1):
+			continue
+		default:
+			return c
+		}
+	}
+
+	return c
+}
+
+// isNumberChar reports whether the rune at i is an ASCII digit or uppercase letter.
+func (f *finder) isNumberChar(i int) bool {
+	if i >= len(f.original) {
+		return false
+	}
+
+	r := f.original[i]
+
+	return r >= '0' && r <= '9' || r >= 'A' && r <= 'Z'
+}
+
+// endsWord reports whether the first l characters of c are not followed by a letter or digit.
+func (f *finder) endsWord(c candidate, l int) bool {
+	end := c.ends[l-1]
+
+	return end == len(f.runes) || !isAlphanumeric(f.runes[end])
+}
+
+// containsIBAN reports whether c starts with an IBAN.
+func (f *finder) containsIBAN(c candidate) bool {
+	for l := ibanMinLength; l <= len(c.chars); l++ {
+		if f.endsWord(c, l) && isIBAN(string(c.chars[:l])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAt looks for a number starting at rune k, returning it and the rune index following it.
+func (f *finder) matchAt(k int, labelled bool, labelCodes []string) (Match, int, bool) {
+	c := f.candidateAt(k)
+	if len(c.chars) < idNumberMinLength || f.containsIBAN(c) {
+		return Match{}, 0, false
+	}
+
+	prefixed := unicode.IsUpper(f.original[k]) && k+1 < len(f.original) && unicode.IsUpper(f.original[k+1])
+	if prefixed {
+		if _, ok := lookupSpec(string(c.chars[:2])); !ok {
+			prefixed = false
+		}
+	}
+
+	var prefixes []string
+	if !prefixed {
+		prefixes = labelCodes
+	}
+
+	for l := len(c.chars); l >= idNumberMinLength; l-- {
+		// The number must not end in the middle of a word.
+		if !f.endsWord(c, l) {
+			continue
+		}
+
+		end := c.ends[l-1]
+
+		if prefixed {
+			if id, err := Parse(string(c.chars[:l])); err == nil {
+				confidence := confidencePrefixed
+				if labelled {
+					confidence = confidenceLabelled
+				}
+
+				return f.match(id, k, end, confidence), end, true
+			}
+
+			continue
+		}
+
+		for _, prefix := range prefixes {
+			if id, err := Parse(prefix + string(c.chars[:l])); err == nil {
+				confidence := confidenceFromLabel
+				if len(prefixes) > 1 {
+					confidence = confidenceAmbiguousLabel
+				}
+
+				return f.match(id, k, end, confidence), end, true
+			}
+		}
+	}
+
+	return Match{}, 0, false
+}
+
+func (f *finder) match(id IDNumber, start, end int, confidence float64) Match {
+	if spec, ok := lookupSpec(id.CountryCode); ok && spec.checkDigits == nil {
+		confidence -= confidenceNoCheckDigits
+	}
+
+	// The slash only had to pass the pattern, ParseWithOptions drops it like the other separators.
+	id.Number = strings.ReplaceAll(id.Number, "/", "")
+
+	return Match{ID: id, Start: f.offsets[start], End: f.offsets[end], Confidence: confidence}
+}
+
+// isIBAN reports whether s is a valid IBAN: a country code, two check digits and an account number,
+// whose ISO 7064 Mod 97, 10 checksum is 1 once the first four characters are moved to the end.
+func isIBAN(s string) bool {
+	if len(s) < ibanMinLength || !isCountryCode(s[:2]) || !isDigits(s[2:4]) {
+		return false
+	}
+
+	return mod97(s[4:]+s[:4]) == 1
+}
@@ -0,0 +1,93 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []vat.Match
+	}{
+		{
+			name: "prefixed numbers",
+			text: "Supplier DE136695976, customer NL 8220.10.690.B01.",
+			want: []vat.Match{
+				{ID: vat.MustParse("DE136695976"), Start: 9, End: 20, Confidence: 0.8},
+				{ID: vat.MustParse("NL822010690B01"), Start: 31, End: 49, Confidence: 0.8},
+			},
+		},
+		{
+			name: "labels",
+			text: "USt-IdNr.: DE 136 695 976\nN° TVA : FR 40 303265045",
+			want: []vat.Match{
+				{ID: vat.MustParse("DE136695976"), Start: 11, End: 25, Confidence: 0.95},
+				{ID: vat.MustParse("FR40303265045"), Start: 36, End: 51, Confidence: 0.95},
+			},
+		},
+		{
+			name: "country from label",
+			text: "P.IVA 00743110157 - NIF: B58378431 - BTW-nr 822010690B01",
+			want: []vat.Match{
+				{ID: vat.MustParse("IT00743110157"), Start: 6, End: 17, Confidence: 0.7},
+				{ID: vat.MustParse("ESB58378431"), Start: 25, End: 34, Confidence: 0.5},
+				{ID: vat.MustParse("NL822010690B01"), Start: 44, End: 56, Confidence: 0.5},
+			},
+		},
+		{
+			name: "IBAN",
+			text: "IBAN: DE89 3704 0044 0532 0130 00",
+		},
+		{
+			name: "cyrillic labels",
+			text: "ИНН 7707083893, УНП: 200988541, ЄДРПОУ 32855961",
+			want: []vat.Match{
+				{ID: vat.MustParse("RU7707083893"), Start: 7, End: 17, Confidence: 0.7},
+				{ID: vat.MustParse("BY200988541"), Start: 27, End: 36, Confidence: 0.7},
+				{ID: vat.MustParse("UA32855961"), Start: 51, End: 59, Confidence: 0.7},
+			},
+		},
+		{
+			name: "INN/KPP",
+			text: "ИНН/КПП 7707083893/773601001, invoice DE136695976/2024",
+			want: []vat.Match{
+				{ID: vat.MustParse("RU7707083893773601001"), Start: 14, End: 34, Confidence: 0.7},
+				{ID: vat.MustParse("DE136695976"), Start: 44, End: 55, Confidence: 0.8},
+			},
+		},
+		{
+			name: "phone numbers and unlabelled digits",
+			text: "Call +49 136 695 976 or 136695976, order 00743110157",
+		},
+		{
+			name: "invalid check digits",
+			text: "VAT: DE136695977",
+		},
+		{
+			name: "part of a word",
+			text: "REFDE136695976 DE1366959761",
+		},
+		{
+			name: "lowercase prefix",
+			text: "sent it 00743110157 times",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := vat.FindAll(tt.text)
+			assert.Equal(t, tt.want, got)
+
+			for _, m := range got {
+				parsed, err := vat.ParseWithOptions(tt.text[m.Start:m.End], vat.ParseOptions{DefaultCountry: m.ID.CountryCode})
+				if assert.NoError(t, err) {
+					assert.Equal(t, m.ID, parsed)
+				}
+			}
+		})
+	}
+}
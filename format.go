@@ -0,0 +1,67 @@
+package vat
+
+import "strings"
+
+// FormatStyle selects the layout IDNumber.Format prints a number in.
+type FormatStyle string
+
+const (
+	// FormatCompact prints the canonical number without any separator, e.g. DE136695976.
+	FormatCompact FormatStyle = "compact"
+	// FormatHuman groups the characters to make the number easy to read and type, e.g. DE 136 695 976.
+	FormatHuman FormatStyle = "human"
+	// FormatOfficial prints the number as the issuing authority does, e.g. CHE-116.281.710 MWST or GB 980 7806 84.
+	// Countries without a distinct official layout use the compact one.
+	FormatOfficial FormatStyle = "official"
+)
+
+// Format prints the canonical form of the number in the given style. The result can be parsed back with Parse.
+// Unknown styles print the compact form.
+func (id IDNumber) Format(style FormatStyle) string {
+	id = id.Canonical()
+	compact := id.String()
+
+	switch style {
+	case FormatHuman, FormatOfficial:
+		if spec, ok := lookupSpec(id.CountryCode); ok {
+			for _, layout := range spec.Layouts[style] {
+				if formatted, fits := applyLayout(layout, compact); fits {
+					return formatted
+				}
+			}
+		}
+
+		if style == FormatHuman {
+			return id.CountryCode + " " + id.Number
+		}
+
+		return compact
+	case FormatCompact:
+		return compact
+	default:
+		return compact
+	}
+}
+
+// applyLayout replaces every # of layout with the next character of s.
+// It reports false if the number of # differs from the length of s.
+func applyLayout(layout, s string) (string, bool) {
+	if strings.Count(layout, "#") != len(s) {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.Grow(len(layout))
+
+	i := 0
+	for _, r := range layout {
+		if r == '#' {
+			b.WriteByte(s[i])
+			i++
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String(), true
+}
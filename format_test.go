@@ -0,0 +1,51 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_Format(t *testing.T) {
+	tests := []struct {
+		input    string
+		compact  string
+		human    string
+		official string
+	}{
+		{"CHE-116.281.710 MWST", "CHE116281710", "CHE-116.281.710", "CHE-116.281.710 MWST"},
+		{"DE136695976", "DE136695976", "DE 136 695 976", "DE 136 695 976"},
+		{"GB980780684", "GB980780684", "GB 980 7806 84", "GB 980 7806 84"},
+		{"GB980780684001", "GB980780684001", "GB 980 7806 84 001", "GB 980 7806 84 001"},
+		{"GBGD001", "GBGD001", "GB GD001", "GB GD001"},
+		{"AT13585627", "ATU13585627", "ATU 1358 5627", "ATU13585627"},
+		{"AU51824753556", "AU51824753556", "AU 51 824 753 556", "AU 51 824 753 556"},
+		{"BE403019261", "BE0403019261", "BE 0403 019 261", "BE0403019261"},
+		{"GR094259216", "EL094259216", "EL 094259216", "EL094259216"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id := vat.MustParse(tt.input)
+			assert.Equal(t, tt.compact, id.Format(vat.FormatCompact))
+			assert.Equal(t, tt.human, id.Format(vat.FormatHuman))
+			assert.Equal(t, tt.official, id.Format(vat.FormatOfficial))
+		})
+	}
+}
+
+func TestIDNumber_Format_RoundTrip(t *testing.T) {
+	for _, country := range vat.Countries() {
+		for _, example := range country.Examples {
+			id := vat.MustParse(example)
+			for _, style := range []vat.FormatStyle{vat.FormatCompact, vat.FormatHuman, vat.FormatOfficial} {
+				formatted := id.Format(style)
+				parsed, err := vat.Parse(formatted)
+				require.NoError(t, err, formatted)
+				assert.True(t, id.Equal(parsed), formatted)
+			}
+		}
+	}
+}
@@ -2,56 +2,17 @@ package vat
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
+	"unicode/utf8"
 )
 
-//nolint:gochecknoglobals // This is a constant map of country codes to their VAT ID number regex patterns.
-
-var patterns = map[string]*regexp.Regexp{
-	"AU": regexp.MustCompile(`[0-9]{11}`),
-	"AT": regexp.MustCompile(`U[A-Z0-9]{8}`),
-	"BE": regexp.MustCompile(`(0[0-9]{9}|[0-9]{10})`),
-	"BG": regexp.MustCompile(`[0-9]{9,10}`),
-	"CH": regexp.MustCompile(
-		`(?:E(?:-| )[0-9]{3}(?:\.| )[0-9]{3}(?:\.| )[0-9]{3}( MWST)?|E[0-9]{9}(?:MWST)?)`,
-	),
-	"CY": regexp.MustCompile(`[0-9]{8}[A-Z]`),
-	"CZ": regexp.MustCompile(`[0-9]{8,10}`),
-	"DE": regexp.MustCompile(`[0-9]{9}`),
-	"DK": regexp.MustCompile(`[0-9]{8}`),
-	"EE": regexp.MustCompile(`[0-9]{9}`),
-	"EL": regexp.MustCompile(`[0-9]{9}`),
-	"ES": regexp.MustCompile(`[A-Z][0-9]{7}[A-Z]|[0-9]{8}[A-Z]|[A-Z][0-9]{8}`),
-	"FI": regexp.MustCompile(`[0-9]{8}`),
-	"FR": regexp.MustCompile(`([A-Z]{2}|[0-9]{2})[0-9]{9}`),
-	// Supposedly the regex for GB numbers is `[0-9]{9}|[0-9]{12}|(GD|HA)[0-9]{3}`,
-	// but our validator service only accepts numbers with 9 or 12 digits following the country code.
-	// Seems like the official site only accepts 9 digits... https://www.gov.uk/check-uk-vat-number
-	"GB": regexp.MustCompile(`([0-9]{9}|[0-9]{12})`),
-	"HR": regexp.MustCompile(`[0-9]{11}`),
-	"HU": regexp.MustCompile(`[0-9]{8}`),
-	"IE": regexp.MustCompile(`[A-Z0-9]{7}[A-Z]|[A-Z0-9]{7}[A-W][A-I]`),
-	"IT": regexp.MustCompile(`[0-9]{11}`),
-	"LT": regexp.MustCompile(`([0-9]{9}|[0-9]{12})`),
-	"LU": regexp.MustCompile(`[0-9]{8}`),
-	"LV": regexp.MustCompile(`[0-9]{11}`),
-	"MT": regexp.MustCompile(`[0-9]{8}`),
-	"NL": regexp.MustCompile(`[0-9]{9}B[0-9]{2}`),
-	"PL": regexp.MustCompile(`[0-9]{10}`),
-	"PT": regexp.MustCompile(`[0-9]{9}`),
-	"RO": regexp.MustCompile(`[0-9]{2,10}`),
-	"SE": regexp.MustCompile(`[0-9]{12}`),
-	"SI": regexp.MustCompile(`[0-9]{8}`),
-	"SK": regexp.MustCompile(`[0-9]{10}`),
-	"XI": regexp.MustCompile(`([0-9]{9}|[0-9]{12})`), // Northern Ireland, same format as GB
-}
-
 const idNumberMinLength = 3
 
+//nolint:recvcheck // Decoding methods need a pointer receiver, like those of time.Time.
 type IDNumber struct {
 	CountryCode string
 	Number      string
+	// Scheme tells the kind of tax identifier, SchemeVAT for all but the North American numbers.
+	Scheme Scheme
 }
 
 func (id IDNumber) String() string {
@@ -67,35 +28,84 @@ func MustParse(s string) IDNumber {
 	return id
 }
 
+// Parse parses a VAT number, checking its format and check digits.
+// Only spaces are removed from the input, see ParseWithOptions for a more lenient variant.
+// Errors are of type *ParseError and match ErrInvalidFormat, ErrInvalidCheckDigits
+// or ErrInvalidCountryCode with errors.Is.
 func Parse(s string) (IDNumber, error) {
-	s = strings.ReplaceAll(s, " ", "")
+	return ParseWithOptions(s, ParseOptions{Strict: true})
+}
 
-	if len(s) < idNumberMinLength {
-		return IDNumber{}, ErrInvalidFormat
+// ParseWithOptions works like Parse, cleaning up the input according to opts first.
+func ParseWithOptions(s string, opts ParseOptions) (IDNumber, error) {
+	// Clean input is used as is, so that parsing it does not allocate.
+	clean := s
+	if !isClean(s) || opts.DefaultCountry != "" || opts.Scheme != SchemeVAT {
+		clean = string(normalize(s, opts).runes)
 	}
 
-	s = strings.ToUpper(s)
+	if utf8.RuneCountInString(clean) < idNumberMinLength {
+		return IDNumber{}, &ParseError{Input: s, Reason: ReasonTooShort, Position: -1}
+	}
+
+	// Registered prefixes are ASCII, so the first two bytes are the prefix if it is known.
 	num := IDNumber{
-		CountryCode: s[:2],
-		Number:      s[2:],
+		CountryCode: clean[:2],
+		Number:      clean[2:],
 	}
 
-	pattern, ok := patterns[num.CountryCode]
+	// Aliases like GR are parsed with the rules of the country they stand for, but kept as is.
+	spec, ok := lookupSpec(num.CountryCode)
 	if !ok {
-		return IDNumber{}, ErrInvalidCountryCode
+		return IDNumber{}, &ParseError{Input: s, Reason: ReasonUnknownPrefix, Position: -1}
+	}
+
+	if !spec.matcher.match(num.Number) {
+		return IDNumber{}, newFormatError(s, normalize(s, opts), num, spec)
 	}
 
-	if !pattern.MatchString(num.Number) {
-		return IDNumber{}, ErrInvalidFormat
+	if !opts.SkipCheckDigits && spec.checkDigits != nil && !spec.checkDigits(num.Number) {
+		return IDNumber{}, &ParseError{
+			Input:          s,
+			CountryCode:    num.CountryCode,
+			Reason:         ReasonCheckDigits,
+			Position:       -1,
+			ExpectedFormat: spec.Format,
+		}
 	}
 
-	if num.CountryCode == "AU" && !validaABN(num.Number) {
-		return IDNumber{}, ErrInvalidFormat
+	num.Scheme = schemeOf(spec.Code, num.Number)
+	if opts.Scheme != SchemeVAT && num.Scheme != opts.Scheme {
+		return IDNumber{}, &ParseError{
+			Input:          s,
+			CountryCode:    num.CountryCode,
+			Reason:         ReasonWrongScheme,
+			Position:       -1,
+			ExpectedFormat: spec.Format,
+		}
 	}
 
 	return num, nil
 }
 
+// ParseBytes works like Parse on a byte slice, e.g. a field read by a CSV decoder.
+// It allocates a single string for the input, which backs the returned IDNumber.
+func ParseBytes(b []byte) (IDNumber, error) {
+	return Parse(string(b))
+}
+
+// isClean reports whether s only holds digits and uppercase ASCII letters, which normalize keeps as is.
+func isClean(s string) bool {
+	for i := range len(s) {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
 // validaABN will check if an ABN is valid.
 // For more information on how this works you can
 // refer to: https://abr.business.gov.au/Help/AbnFormat
@@ -1,6 +1,7 @@
 package vat_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/creativefabrica/vat"
@@ -59,14 +60,173 @@ func TestParse(t *testing.T) {
 				s: "AU41824753556",
 			},
 			want:    vat.IDNumber{},
-			wantErr: vat.ErrInvalidFormat,
+			wantErr: vat.ErrInvalidCheckDigits,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := vat.Parse(tt.args.s)
 			assert.Equal(t, tt.want, got)
-			require.ErrorIs(t, tt.wantErr, err)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParse_FullMatch(t *testing.T) {
+	tests := map[string]struct {
+		valid   string
+		invalid []string
+	}{
+		"AD": {"ADU132950X", []string{"ADU132950", "ADB132950X", "ADF700000X", "ADA600000X", "ADU132950XYZ"}},
+		"AL": {"ALJ91402501L", []string{"ALJ91402501", "ALN91402501L", "AL191402501L", "ALJ91402501LXYZ"}},
+		"AM": {"AM02500111", []string{"AM025001111", "AM0250011", "AMX02500111", "AM02500111XYZ"}},
+		"AT": {"ATU13585627", []string{"ATU135856270", "ATU1358562", "ATXU13585627", "ATU13585627XYZ"}},
+		"AU": {"AU51824753556", []string{"AU518247535560", "AU5182475355", "AUX51824753556", "AU51824753556XYZ"}},
+		"BA": {"BA4200225150005", []string{"BA5200225150005", "BA20022515000", "BAX200225150005", "BA200225150005XYZ"}},
+		"BE": {"BE0403019261", []string{"BE04030192611", "BE040301926", "BEX0403019261", "BE0403019261XYZ"}},
+		"BG": {"BG175074752", []string{"BG17507475212", "BG17507475", "BGX175074752", "BG175074752XYZ"}},
+		"BY": {"BY200988541", []string{"BY800988541", "BYMX1953684", "BYMA19536841", "BY20098854", "BY200988541XYZ"}},
+		"CA": {"CA123456782RT0001", []string{"CA123456782XX0001", "CA12345678", "CA123456782RT001", "CA123456782RT0001XYZ"}},
+		"CH": {"CHE116281710", []string{"CHE1162817100", "CHE11628171", "CHXE116281710", "CHE116281710XYZ"}},
+		"CY": {"CY10259033P", []string{"CY10259033PP", "CY1025903P", "CYX10259033P", "CY10259033PXYZ"}},
+		"CZ": {"CZ25123891", []string{"CZ25123891234", "CZ2512389", "CZX25123891", "CZ25123891XYZ"}},
+		"DE": {"DE136695976", []string{"DE1366959769", "DE13669597", "DEX136695976", "DE136695976999999"}},
+		"DK": {"DK13585628", []string{"DK135856281", "DK1358562", "DKX13585628", "DK13585628XYZ"}},
+		"EE": {"EE100931558", []string{"EE1009315581", "EE10093155", "EEX100931558", "EE100931558XYZ"}},
+		"EL": {"EL094259216", []string{"EL0942592161", "EL09425921", "ELX094259216", "EL094259216XYZ"}},
+		"ES": {"ESA13585625", []string{"ESA135856255", "ESA1358562", "ESXA13585625", "ESA13585625XYZ"}},
+		"FI": {"FI20774740", []string{"FI207747401", "FI2077474", "FIX20774740", "FI20774740XYZ"}},
+		"FR": {"FR40303265045", []string{"FR403032650451", "FR4030326504", "FRX40303265045", "FR40303265045XYZ"}},
+		"GB": {"GB980780684", []string{"GB9807806841", "GB98078068", "GBX980780684", "GB980780684XYZ"}},
+		"GE": {"GE204470740", []string{"GE2044707401", "GE20447074", "GEX204470740", "GE204470740XYZ"}},
+		"HR": {"HR33392005961", []string{"HR333920059611", "HR3339200596", "HRX33392005961", "HR33392005961XYZ"}},
+		"HU": {"HU12892312", []string{"HU128923121", "HU1289231", "HUX12892312", "HU12892312XYZ"}},
+		"IE": {"IE6433435F", []string{"IE6433435FAA", "IE643343F", "IE-6433435F", "IE6433435F123"}},
+		"IS": {"IS4504013150", []string{"IS1234", "IS1234567", "IS45040131501", "ISX123456", "IS123456XYZ"}},
+		"IT": {"IT00743110157", []string{"IT007431101571", "IT0074311015", "ITX00743110157", "IT00743110157XYZ"}},
+		"KZ": {"KZ971240001315", []string{"KZ9712400013151", "KZ97124000131", "KZX971240001315", "KZ971240001315XYZ"}},
+		"LI": {"LI54321", []string{"LI543210", "LI5432", "LIX54321", "LI54321XYZ"}},
+		"LT": {"LT119511515", []string{"LT1195115151", "LT11951151", "LTX119511515", "LT119511515XYZ"}},
+		"LU": {"LU15027442", []string{"LU150274421", "LU1502744", "LUX15027442", "LU15027442XYZ"}},
+		"LV": {"LV40003521600", []string{"LV400035216001", "LV4000352160", "LVX40003521600", "LV40003521600XYZ"}},
+		"MD": {"MD1008600038413", []string{"MD10086000384131", "MD100860003841", "MDX1008600038413", "MD1008600038413XYZ"}},
+		"ME": {"ME02655284", []string{"ME026552841", "ME0265528", "MEX02655284", "ME02655284XYZ"}},
+		"MK": {"MK4030000375897", []string{"MK40300003758971", "MK403000037589", "MKX4030000375897", "MK4030000375897XYZ"}},
+		"MT": {"MT11679112", []string{"MT116791121", "MT1167911", "MTX11679112", "MT11679112XYZ"}},
+		"MX": {"MXGODE561231GR8", []string{"MXGODE561231GRX", "MXGO561231GR8", "MXGODEX561231GR8", "MX0ODE561231GR8"}},
+		"NL": {"NL822010690B01", []string{"NL822010690B011", "NL822010690B0", "NLX822010690B01", "NL822010690B01XYZ"}},
+		"NO": {"NO995525828MVA", []string{"NO9955258280", "NO99552582MVA", "NOX995525828", "NO995525828XYZ"}},
+		"PL": {"PL8567346215", []string{"PL85673462151", "PL856734621", "PLX8567346215", "PL8567346215XYZ"}},
+		"PT": {"PT501964843", []string{"PT5019648431", "PT50196484", "PTX501964843", "PT501964843XYZ"}},
+		"RO": {"RO18547290", []string{"RO18547290123", "RO1", "ROX18547290", "RO18547290XYZ"}},
+		"RS": {"RS101134702", []string{"RS1011347021", "RS10113470", "RSX101134702", "RS101134702XYZ"}},
+		"RU": {
+			"RU7707083893/773601001",
+			[]string{"RU77070838931", "RU7707083893/77360100", "RUX7707083893", "RU7707083893XYZ"},
+		},
+		"SE": {"SE556188840401", []string{"SE5561888404011", "SE55618884040", "SEX556188840401", "SE556188840401XYZ"}},
+		"SI": {"SI50223054", []string{"SI502230541", "SI5022305", "SIX50223054", "SI50223054XYZ"}},
+		"SK": {"SK2022749619", []string{"SK20227496191", "SK202274961", "SKX2022749619", "SK2022749619XYZ"}},
+		"SM": {"SM24165", []string{"SM241650", "SM2416", "SMX24165", "SM24165XYZ"}},
+		"UA": {"UA32855961", []string{"UA328559611", "UA3285596", "UAX32855961", "UA32855961XYZ"}},
+		"US": {"US52-1234567", []string{"US001234567", "US07-1234567", "US5212345678", "US52123456", "US52--1234567"}},
+		"XI": {"XI980780684", []string{"XI9807806841", "XI98078068", "XIX980780684", "XI980780684XYZ"}},
+	}
+	for country, tt := range tests {
+		t.Run(country, func(t *testing.T) {
+			got, err := vat.Parse(tt.valid)
+			require.NoError(t, err)
+			assert.Equal(t, country, got.CountryCode)
+
+			for _, s := range tt.invalid {
+				_, err = vat.Parse(s)
+				require.ErrorIs(t, err, vat.ErrInvalidFormat, s)
+			}
 		})
 	}
 }
+
+func TestParseBytes(t *testing.T) {
+	got, err := vat.ParseBytes([]byte("NL822010690B01"))
+	require.NoError(t, err)
+	assert.Equal(t, vat.IDNumber{CountryCode: "NL", Number: "822010690B01"}, got)
+
+	_, err = vat.ParseBytes([]byte("NL822010690B00"))
+	require.ErrorIs(t, err, vat.ErrInvalidFormat)
+}
+
+func TestParse_NoAllocations(t *testing.T) {
+	clean := regexp.MustCompile(`^[0-9A-Z]+$`)
+	for _, c := range vat.Countries() {
+		for _, example := range c.Examples {
+			if !clean.MatchString(example) {
+				continue
+			}
+
+			allocs := testing.AllocsPerRun(10, func() {
+				_, _ = vat.Parse(example)
+			})
+			assert.Zero(t, allocs, example)
+		}
+	}
+}
+
+func TestParse_SameAsPattern(t *testing.T) {
+	const pattern = `[0-9]{2}(?:[A-Z]|É)?-?[0-9]?|Z+`
+	require.NoError(t, registerTestCountry(t, vat.CountrySpec{
+		Code:      "QP",
+		Name:      "Pattern test",
+		LocalName: "Pattern test",
+		Pattern:   pattern,
+		Format:    "QP99",
+		Examples:  []string{"QP12"},
+	}))
+
+	re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	alphabet := []string{"0", "9", "A", "Z", "-", "É"}
+	inputs := []string{""}
+	for range 5 {
+		var longer []string
+		for _, input := range inputs {
+			for _, c := range alphabet {
+				longer = append(longer, input+c)
+			}
+		}
+
+		inputs = longer
+		for _, number := range inputs {
+			_, err := vat.Parse("QP" + number)
+			assert.Equal(t, re.MatchString(number), err == nil, number)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = vat.Parse("NL822010690B01")
+	}
+}
+
+func BenchmarkParse_Invalid(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = vat.Parse("NL822010690B00")
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	input := []byte("NL822010690B01")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = vat.ParseBytes(input)
+	}
+}
+
+func BenchmarkParseWithOptions(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = vat.ParseWithOptions("VAT: nl 8220.10.690 b01", vat.ParseOptions{})
+	}
+}
@@ -0,0 +1,18 @@
+// Package redact removes the data clients must not leak from the errors they return.
+package redact
+
+import (
+	"errors"
+	"net/url"
+)
+
+// URL replaces the URL of the errors returned by an HTTP client with redacted,
+// as the URLs of registry lookups hold the number being looked up, and sometimes credentials.
+func URL(err error, redacted string) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return &url.Error{Op: urlErr.Op, URL: redacted, Err: urlErr.Err}
+	}
+
+	return err
+}
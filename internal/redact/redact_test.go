@@ -0,0 +1,23 @@
+package redact_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat/internal/redact"
+)
+
+func TestURL(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	err := &url.Error{Op: "Get", URL: "https://example.com/lookup/553557881", Err: errTimeout}
+
+	redacted := redact.URL(err, "https://example.com/lookup/55*****81")
+	assert.EqualError(t, redacted, `Get "https://example.com/lookup/55*****81": timeout`)
+	assert.ErrorIs(t, redacted, errTimeout)
+
+	other := errors.New("other")
+	assert.Equal(t, other, redact.URL(other, "https://example.com"))
+}
@@ -0,0 +1,80 @@
+package vat
+
+import (
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// MaskOptions configures how VAT numbers are masked by Masked, MaskString and in logs.
+type MaskOptions struct {
+	// Visible is the number of trailing characters left visible. At most half of the characters
+	// after the country code are ever shown, so short numbers are not revealed in full.
+	Visible int
+	// Char replaces the hidden characters, * if zero.
+	Char rune
+}
+
+const defaultMaskVisible = 4
+
+//nolint:gochecknoglobals // The masking options are process wide, like the default slog logger.
+var maskOptions atomic.Pointer[MaskOptions]
+
+// SetMaskOptions changes how numbers are masked by Masked, MaskString and in logs.
+// By default the last 4 characters are shown, e.g. ES*****678Z. It is safe for concurrent use.
+func SetMaskOptions(opts MaskOptions) {
+	maskOptions.Store(&opts)
+}
+
+func currentMaskOptions() MaskOptions {
+	if opts := maskOptions.Load(); opts != nil {
+		return *opts
+	}
+
+	return MaskOptions{Visible: defaultMaskVisible}
+}
+
+// Masked returns the number with all but its last characters hidden, e.g. ES*****678Z.
+// Use it wherever the number ends up in logs, traces or error reports: the VAT numbers of sole traders
+// are often derived from personal identifiers.
+func (id IDNumber) Masked() string {
+	return id.CountryCode + mask(id.Number, currentMaskOptions())
+}
+
+// LogValue implements slog.LogValuer, so numbers are masked when logged.
+func (id IDNumber) LogValue() slog.Value {
+	return slog.StringValue(id.Masked())
+}
+
+// MaskString masks a VAT number that could not be parsed, keeping its first two characters like Masked.
+func MaskString(s string) string {
+	runes := []rune(s)
+	prefix := min(len(runes), 2)
+
+	return string(runes[:prefix]) + mask(string(runes[prefix:]), currentMaskOptions())
+}
+
+func mask(s string, opts MaskOptions) string {
+	runes := []rune(s)
+	visible := max(min(opts.Visible, len(runes)/2), 0)
+	hidden := len(runes) - visible
+
+	char := opts.Char
+	if char == 0 {
+		char = '*'
+	}
+
+	return strings.Repeat(string(char), hidden) + string(runes[hidden:])
+}
+
+// LogValue implements slog.LogValuer. The number is masked and the registered name and address,
+// which are personal data for sole traders, are left out.
+func (r ValidationResult) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("id", r.ID),
+		slog.Bool("valid", r.Valid),
+		slog.String("outcome", string(r.Outcome)),
+		slog.String("provider", string(r.Provider)),
+		slog.String("consultation_number", r.ConsultationNumber),
+	)
+}
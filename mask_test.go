@@ -0,0 +1,52 @@
+package vat_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_Masked(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "ES12345678Z", want: "ES*****678Z"},
+		{input: "IT00743110157", want: "IT*******0157"},
+		{input: "GBGD001", want: "GB***01"},
+		{input: "RO18", want: "RO*8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			id := vat.IDNumber{CountryCode: tt.input[:2], Number: tt.input[2:]}
+			assert.Equal(t, tt.want, id.Masked())
+			assert.Equal(t, tt.want, vat.MaskString(tt.input))
+		})
+	}
+
+	assert.Equal(t, "X", vat.MaskString("X"))
+}
+
+func TestSetMaskOptions(t *testing.T) {
+	vat.SetMaskOptions(vat.MaskOptions{Visible: 2, Char: '#'})
+	defer vat.SetMaskOptions(vat.MaskOptions{Visible: 4})
+
+	assert.Equal(t, "ES#######8Z", vat.MustParse("ES12345678Z").Masked())
+}
+
+func TestIDNumber_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	id := vat.MustParse("DE136695976")
+	logger.Info("validated", "vat_number", id, "result", vat.ValidationResult{ID: id, Valid: true, Name: "Jane Doe"})
+
+	assert.Contains(t, buf.String(), "vat_number=DE*****5976")
+	assert.Contains(t, buf.String(), "result.id=DE*****5976")
+	assert.NotContains(t, buf.String(), "136695976")
+	assert.NotContains(t, buf.String(), "Jane Doe")
+}
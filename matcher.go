@@ -0,0 +1,188 @@
+package vat
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// asciiSize is the number of bytes the DFA of a matcher has transitions for.
+	asciiSize = 128
+	// maxMatcherStates bounds the size of the DFA built for a pattern.
+	maxMatcherStates = 1024
+	// deadState is the DFA state without a way to a match.
+	deadState = 0
+)
+
+// matcher checks numbers against the pattern of a country without allocating.
+// Its DFA only covers ASCII input and falls back to the regexp for anything else,
+// so it accepts exactly the numbers the regexp accepts.
+type matcher struct {
+	pattern *regexp.Regexp
+	// next holds the transitions of the DFA, nil if the pattern is too large or uses
+	// assertions other than ^ and $.
+	next   [][asciiSize]uint16
+	accept []bool
+	start  uint16
+}
+
+func newMatcher(pattern *regexp.Regexp, prog partialProg) *matcher {
+	m := &matcher{pattern: pattern}
+	if !m.buildDFA(prog) {
+		m.next, m.accept = nil, nil
+	}
+
+	return m
+}
+
+// match reports whether the whole number matches the pattern.
+func (m *matcher) match(number string) bool {
+	if m.next == nil {
+		return m.pattern.MatchString(number)
+	}
+
+	state := m.start
+	for i := range len(number) {
+		c := number[i]
+		if c >= asciiSize {
+			return m.pattern.MatchString(number)
+		}
+
+		state = m.next[state][c]
+		if state == deadState {
+			return false
+		}
+	}
+
+	return m.accept[state]
+}
+
+// buildDFA runs the subset construction on prog. It returns false if prog can't be turned into a DFA.
+func (m *matcher) buildDFA(prog partialProg) bool {
+	for _, inst := range prog.Inst {
+		if inst.Op == syntax.InstEmptyWidth &&
+			syntax.EmptyOp(inst.Arg)&^(syntax.EmptyBeginText|syntax.EmptyEndText) != 0 {
+			return false
+		}
+	}
+
+	states := map[string]uint16{}
+	var sets [][]uint32
+
+	// The start state is kept apart, as ^ only holds there.
+	add := func(set []uint32, start bool) uint16 {
+		if len(set) == 0 {
+			return deadState
+		}
+
+		slices.Sort(set)
+		key := setKey(set, start)
+		if state, ok := states[key]; ok {
+			return state
+		}
+
+		state := uint16(len(m.next))
+		states[key] = state
+		sets = append(sets, set)
+		m.next = append(m.next, [asciiSize]uint16{})
+		m.accept = append(m.accept, false)
+
+		return state
+	}
+
+	// The dead state has no set and transitions to itself.
+	m.next = append(m.next, [asciiSize]uint16{})
+	m.accept = append(m.accept, false)
+	sets = append(sets, nil)
+
+	m.start = add(prog.emptyClosure(nil, uint32(prog.Start), syntax.EmptyBeginText), true)
+
+	for state := 1; state < len(sets); state++ {
+		if len(sets) > maxMatcherStates {
+			return false
+		}
+
+		set := sets[state]
+		flags := syntax.EmptyEndText
+		if uint16(state) == m.start {
+			flags |= syntax.EmptyBeginText
+		}
+
+		for _, pc := range set {
+			if slices.Contains(prog.emptyClosure(nil, pc, flags), prog.matchPC()) {
+				m.accept[state] = true
+
+				break
+			}
+		}
+
+		for c := range rune(asciiSize) {
+			var next []uint32
+			for _, pc := range set {
+				inst := prog.Inst[pc]
+				if isRuneInst(inst.Op) && matchesRune(inst, c) {
+					next = prog.emptyClosure(next, inst.Out, 0)
+				}
+			}
+
+			m.next[state][c] = add(next, false)
+		}
+	}
+
+	return len(sets) <= maxMatcherStates
+}
+
+// emptyClosure adds pc and the instructions reachable from it without consuming a rune to set,
+// following empty width assertions only if flags satisfy them. Blocked assertions stay in the set.
+func (p partialProg) emptyClosure(set []uint32, pc uint32, flags syntax.EmptyOp) []uint32 {
+	if slices.Contains(set, pc) {
+		return set
+	}
+
+	set = append(set, pc)
+
+	inst := p.Inst[pc]
+	switch inst.Op {
+	case syntax.InstAlt, syntax.InstAltMatch:
+		set = p.emptyClosure(set, inst.Out, flags)
+		set = p.emptyClosure(set, inst.Arg, flags)
+	case syntax.InstCapture, syntax.InstNop:
+		set = p.emptyClosure(set, inst.Out, flags)
+	case syntax.InstEmptyWidth:
+		if syntax.EmptyOp(inst.Arg)&^flags == 0 {
+			set = p.emptyClosure(set, inst.Out, flags)
+		}
+	case syntax.InstMatch, syntax.InstFail, syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny,
+		syntax.InstRuneAnyNotNL:
+	}
+
+	return set
+}
+
+// matchPC returns the index of the match instruction of p.
+func (p partialProg) matchPC() uint32 {
+	for pc, inst := range p.Inst {
+		if inst.Op == syntax.InstMatch {
+			return uint32(pc)
+		}
+	}
+
+	return uint32(len(p.Inst))
+}
+
+func setKey(set []uint32, start bool) string {
+	var b strings.Builder
+	if start {
+		b.WriteByte('^')
+	}
+
+	for _, pc := range set {
+		b.WriteString(strconv.FormatUint(uint64(pc), 10))
+		b.WriteByte(',')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,222 @@
+package vat
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// ParseErrorReason tells why a VAT number could not be parsed.
+type ParseErrorReason string
+
+const (
+	// ReasonTooShort means the number has fewer characters than its country allows.
+	ReasonTooShort ParseErrorReason = "too_short"
+	// ReasonTooLong means the number has more characters than its country allows.
+	ReasonTooLong ParseErrorReason = "too_long"
+	// ReasonInvalidCharacter means the character at Position can't appear in numbers of the country.
+	ReasonInvalidCharacter ParseErrorReason = "invalid_character"
+	// ReasonInvalidFormat means the characters and length are plausible, but not in the expected layout.
+	ReasonInvalidFormat ParseErrorReason = "invalid_format"
+	// ReasonCheckDigits means the number has the expected layout but its check digits don't match.
+	ReasonCheckDigits ParseErrorReason = "check_digits"
+	// ReasonUnknownPrefix means the first two characters are not a supported country code.
+	ReasonUnknownPrefix ParseErrorReason = "unknown_prefix"
+	// ReasonWrongScheme means the number is valid, but of another scheme than ParseOptions.Scheme.
+	ReasonWrongScheme ParseErrorReason = "wrong_scheme"
+)
+
+// ParseError describes why Parse rejected its input.
+// It matches ErrInvalidFormat, or ErrInvalidCountryCode for ReasonUnknownPrefix, with errors.Is.
+// Errors with ReasonCheckDigits also match ErrInvalidCheckDigits.
+type ParseError struct {
+	// Input is the string passed to Parse.
+	Input string
+	// CountryCode is the detected country code, empty if the prefix is unknown.
+	CountryCode string
+	// Reason is the machine-readable cause of the error.
+	Reason ParseErrorReason
+	// Position is the byte offset in Input of the offending character for ReasonInvalidCharacter, -1 otherwise.
+	Position int
+	// ExpectedFormat describes the format of the detected country, see Country.
+	ExpectedFormat string
+}
+
+func (e *ParseError) Error() string {
+	msg := "invalid vat number: " + e.Reason.description()
+	if e.Reason == ReasonInvalidCharacter {
+		msg += fmt.Sprintf(" at position %d", e.Position)
+	}
+
+	if e.ExpectedFormat != "" {
+		msg += fmt.Sprintf(" (expected %s)", e.ExpectedFormat)
+	}
+
+	return msg
+}
+
+// description returns the reason as it reads in error messages.
+func (r ParseErrorReason) description() string {
+	switch r {
+	case ReasonTooShort:
+		return "too short"
+	case ReasonTooLong:
+		return "too long"
+	case ReasonInvalidCharacter:
+		return "invalid character"
+	case ReasonInvalidFormat:
+		return "invalid format"
+	case ReasonCheckDigits:
+		return "check digits mismatch"
+	case ReasonUnknownPrefix:
+		return "unknown country code"
+	case ReasonWrongScheme:
+		return "wrong scheme"
+	default:
+		return string(r)
+	}
+}
+
+func (e *ParseError) Unwrap() error {
+	switch e.Reason {
+	case ReasonUnknownPrefix:
+		return ErrInvalidCountryCode
+	case ReasonCheckDigits:
+		return ErrInvalidCheckDigits
+	case ReasonTooShort, ReasonTooLong, ReasonInvalidCharacter, ReasonInvalidFormat, ReasonWrongScheme:
+		return ErrInvalidFormat
+	default:
+		return ErrInvalidFormat
+	}
+}
+
+// newFormatError tells why num, parsed from input normalized to n, does not match the pattern of spec.
+func newFormatError(input string, n normalized, num IDNumber, spec *countrySpec) *ParseError {
+	err := &ParseError{
+		Input:          input,
+		CountryCode:    num.CountryCode,
+		Reason:         ReasonInvalidFormat,
+		Position:       -1,
+		ExpectedFormat: spec.Format,
+	}
+
+	re, parseErr := syntax.Parse(spec.pattern.String(), syntax.Perl)
+	if parseErr != nil {
+		return err
+	}
+
+	for i, r := range n.runes[2:] {
+		if !canMatchRune(re, r) {
+			err.Reason = ReasonInvalidCharacter
+			err.Position = n.offsets[2+i]
+
+			return err
+		}
+	}
+
+	minLength, maxLength := lengthBounds(re)
+	switch length := len(n.runes) - 2; {
+	case length < minLength:
+		err.Reason = ReasonTooShort
+	case maxLength >= 0 && length > maxLength:
+		err.Reason = ReasonTooLong
+	}
+
+	return err
+}
+
+// canMatchRune reports whether r appears anywhere in the language of re.
+func canMatchRune(re *syntax.Regexp, r rune) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, lit := range re.Rune {
+			if lit == r {
+				return true
+			}
+		}
+
+		return false
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if re.Rune[i] <= r && r <= re.Rune[i+1] {
+				return true
+			}
+		}
+
+		return false
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpCapture, syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat,
+		syntax.OpConcat, syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if canMatchRune(sub, r) {
+				return true
+			}
+		}
+
+		return false
+	case syntax.OpNoMatch, syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return false
+	default:
+		return false
+	}
+}
+
+// lengthBounds returns the minimum and maximum number of characters matched by re.
+// The maximum is -1 if it is unbounded.
+func lengthBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune), len(re.Rune)
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1, 1
+	case syntax.OpCapture:
+		return lengthBounds(re.Sub[0])
+	case syntax.OpQuest:
+		_, maxLength := lengthBounds(re.Sub[0])
+
+		return 0, maxLength
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		minLength, _ := lengthBounds(re.Sub[0])
+
+		return minLength, -1
+	case syntax.OpRepeat:
+		minLength, maxLength := lengthBounds(re.Sub[0])
+		if re.Max < 0 || maxLength < 0 {
+			return minLength * re.Min, -1
+		}
+
+		return minLength * re.Min, maxLength * re.Max
+	case syntax.OpConcat:
+		minTotal, maxTotal := 0, 0
+		for _, sub := range re.Sub {
+			minLength, maxLength := lengthBounds(sub)
+			minTotal += minLength
+			if maxTotal < 0 || maxLength < 0 {
+				maxTotal = -1
+			} else {
+				maxTotal += maxLength
+			}
+		}
+
+		return minTotal, maxTotal
+	case syntax.OpAlternate:
+		minAll, maxAll := lengthBounds(re.Sub[0])
+		for _, sub := range re.Sub[1:] {
+			minLength, maxLength := lengthBounds(sub)
+			minAll = min(minAll, minLength)
+			if maxAll >= 0 && (maxLength < 0 || maxLength > maxAll) {
+				maxAll = maxLength
+			}
+		}
+
+		return minAll, maxAll
+	case syntax.OpNoMatch, syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return 0, 0
+	default:
+		return 0, 0
+	}
+}
@@ -0,0 +1,112 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestParse_ParseError(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    vat.ParseError
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			input:   "  ",
+			want:    vat.ParseError{Input: "  ", Reason: vat.ReasonTooShort, Position: -1},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:    "unknown prefix",
+			input:   "AR822010690B01",
+			want:    vat.ParseError{Input: "AR822010690B01", Reason: vat.ReasonUnknownPrefix, Position: -1},
+			wantErr: vat.ErrInvalidCountryCode,
+		},
+		{
+			name:  "too short",
+			input: "DE12345678",
+			want: vat.ParseError{
+				Input:          "DE12345678",
+				CountryCode:    "DE",
+				Reason:         vat.ReasonTooShort,
+				Position:       -1,
+				ExpectedFormat: "DE999999999",
+			},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:  "too long",
+			input: "DE123456789999999",
+			want: vat.ParseError{
+				Input:          "DE123456789999999",
+				CountryCode:    "DE",
+				Reason:         vat.ReasonTooLong,
+				Position:       -1,
+				ExpectedFormat: "DE999999999",
+			},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:  "invalid character",
+			input: "nl 822010690-B01",
+			want: vat.ParseError{
+				Input:          "nl 822010690-B01",
+				CountryCode:    "NL",
+				Reason:         vat.ReasonInvalidCharacter,
+				Position:       12,
+				ExpectedFormat: "NL999999999B99",
+			},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:  "invalid format",
+			input: "NL8220106900B1",
+			want: vat.ParseError{
+				Input:          "NL8220106900B1",
+				CountryCode:    "NL",
+				Reason:         vat.ReasonInvalidFormat,
+				Position:       -1,
+				ExpectedFormat: "NL999999999B99",
+			},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:  "check digits",
+			input: "DE136695978",
+			want: vat.ParseError{
+				Input:          "DE136695978",
+				CountryCode:    "DE",
+				Reason:         vat.ReasonCheckDigits,
+				Position:       -1,
+				ExpectedFormat: "DE999999999",
+			},
+			wantErr: vat.ErrInvalidCheckDigits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vat.Parse(tt.input)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			var parseErr *vat.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.want, *parseErr)
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	_, err := vat.Parse("NL 822010690-B01")
+	require.EqualError(t, err, "invalid vat number: invalid character at position 12 (expected NL999999999B99)")
+	assert.NotErrorIs(t, err, vat.ErrInvalidCountryCode)
+
+	_, err = vat.Parse("DE136695978")
+	require.EqualError(t, err, "invalid vat number: check digits mismatch (expected DE999999999)")
+	require.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+}
@@ -0,0 +1,190 @@
+package vat
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ParseOptions controls how ParseWithOptions cleans up its input before parsing it.
+type ParseOptions struct {
+	// DefaultCountry is the country code to use when the input does not start with a known one,
+	// e.g. the country of the billing address. ISO 3166 codes like GR are accepted too.
+	DefaultCountry string
+	// Strict keeps the behaviour of Parse: only spaces are removed and letters are uppercased.
+	// Otherwise full-width characters are folded to ASCII, any kind of whitespace and common separators
+	// are removed, and a leading label like "VAT:" or "USt-IdNr." is dropped.
+	Strict bool
+	// Scheme restricts parsing to numbers of the scheme, e.g. SchemeEIN, when it is not SchemeVAT.
+	// Its country code is added to numbers that don't start with it, so that the number can be typed as issued,
+	// and numbers of other schemes fail with ErrInvalidFormat.
+	Scheme Scheme
+	// SkipCheckDigits accepts numbers with the right format but wrong check digits, e.g. the test numbers
+	// of the HMRC sandbox, which don't carry valid ones. Don't use it for numbers entered by users.
+	SkipCheckDigits bool
+}
+
+// labels are the usual captions printed in front of VAT numbers, uppercased and without separators.
+//
+//nolint:gochecknoglobals // This is a constant list of VAT number labels.
+var labels = []string{
+	"VAT", "VATNO", "VATNR", "VATNUMBER", "VATID", "VATIN", "VATREGNO", "VATREGISTRATIONNUMBER",
+	"TAXID", "TIN",
+	"USTIDNR", "USTID", "USTIDNUMMER", "UID", "UIDNR", "MWST", "MWSTNR",
+	"TVA", "NTVA", "NOTVA", "NUMEROTVA", "TVAINTRACOM", "TVAINTRACOMMUNAUTAIRE",
+	"BTW", "BTWNR", "BTWNUMMER", "BTWID",
+	"IVA", "PIVA", "PARTITAIVA", "NIF", "NIFIVA", "CIF", "NIPC",
+	"MOMS", "MOMSNR", "MOMSREGNR", "ALV", "ALVNRO", "PVM", "PVMKODAS", "KMKR", "OIB", "DDV", "DPH", "DIČ", "NIP",
+	"ΑΦΜ", "ABN", "MVA", "MVANR", "ORGNR", "VSK", "VSKNR", "NRT",
+	"PIB", "ПИБ", "EDB", "ЕДБ", "NIPT", "IDNO", "EDRPOU", "ЄДРПОУ", "IPN", "ІПН", "UNP", "УНП", "BIN", "БИН",
+	"INN", "ИНН", "INNKPP", "ИННКПП",
+	"BN", "GST", "HST", "GSTHST", "TPS", "TVH", "TPSTVH", "QST", "TVQ", "EIN", "FEIN", "RFC",
+}
+
+// normalized is a cleaned up input, with the byte offset in the input of every rune.
+// Runes that are not in the input, like a default country code, have an offset of -1.
+type normalized struct {
+	runes   []rune
+	offsets []int
+}
+
+func normalize(s string, opts ParseOptions) normalized {
+	n := normalized{
+		runes:   make([]rune, 0, len(s)),
+		offsets: make([]int, 0, len(s)),
+	}
+
+	for i, r := range s {
+		if !opts.Strict {
+			r = foldWidth(r)
+		}
+
+		n.runes = append(n.runes, unicode.ToUpper(r))
+		n.offsets = append(n.offsets, i)
+	}
+
+	if !opts.Strict {
+		n.dropLabel()
+	}
+
+	n.removeSeparators(opts.Strict)
+
+	switch countryCode := opts.Scheme.CountryCode(); {
+	case countryCode != "":
+		if !strings.HasPrefix(string(n.runes), countryCode) {
+			n.prependCountry(countryCode)
+		}
+	case opts.DefaultCountry != "":
+		n.addCountry(CountryCodeFromISO(strings.ToUpper(opts.DefaultCountry)))
+	}
+
+	return n
+}
+
+// foldWidth maps full-width forms of ASCII characters, as typed with CJK input methods, to ASCII.
+func foldWidth(r rune) rune {
+	const (
+		fullWidthFirst  = 0xFF01 // FULLWIDTH EXCLAMATION MARK
+		fullWidthLast   = 0xFF5E // FULLWIDTH TILDE
+		fullWidthOffset = fullWidthFirst - '!'
+	)
+	if r >= fullWidthFirst && r <= fullWidthLast {
+		return r - fullWidthOffset
+	}
+
+	return r
+}
+
+// isSeparator reports whether r is used to group or introduce the characters of a VAT number.
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || unicode.Is(unicode.Pd, r) || strings.ContainsRune(".,/\\_:#°º()'", r)
+}
+
+// dropLabel removes a leading label, along with the separators around it.
+func (n *normalized) dropLabel() {
+	n.trimSeparators()
+
+	longest := 0
+	for _, label := range labels {
+		longest = max(longest, n.matchLabel(label))
+	}
+
+	if longest > 0 {
+		n.runes = n.runes[longest:]
+		n.offsets = n.offsets[longest:]
+		n.trimSeparators()
+	}
+}
+
+// matchLabel returns the number of runes the label takes at the start of n, ignoring separators,
+// or 0 if n does not start with the label followed by a separator.
+func (n *normalized) matchLabel(label string) int {
+	i := 0
+	for _, want := range label {
+		for i < len(n.runes) && isSeparator(n.runes[i]) {
+			i++
+		}
+
+		if i == len(n.runes) || n.runes[i] != want {
+			return 0
+		}
+
+		i++
+	}
+
+	if i == len(n.runes) || !isSeparator(n.runes[i]) {
+		return 0
+	}
+
+	return i
+}
+
+func (n *normalized) trimSeparators() {
+	i := 0
+	for i < len(n.runes) && isSeparator(n.runes[i]) {
+		i++
+	}
+
+	n.runes = n.runes[i:]
+	n.offsets = n.offsets[i:]
+}
+
+// removeSeparators drops the spaces in strict mode, and every separator otherwise.
+func (n *normalized) removeSeparators(strict bool) {
+	kept := 0
+	for i, r := range n.runes {
+		if r == ' ' || (!strict && isSeparator(r)) {
+			continue
+		}
+
+		n.runes[kept] = r
+		n.offsets[kept] = n.offsets[i]
+		kept++
+	}
+
+	n.runes = n.runes[:kept]
+	n.offsets = n.offsets[:kept]
+}
+
+// addCountry prepends the country code, unless n already starts with a known one.
+func (n *normalized) addCountry(countryCode string) {
+	if len(n.runes) >= 2 {
+		if _, ok := lookupSpec(string(n.runes[:2])); ok {
+			return
+		}
+	}
+
+	n.prependCountry(countryCode)
+}
+
+// prependCountry adds the country code in front of n.
+func (n *normalized) prependCountry(countryCode string) {
+	prefix := []rune(countryCode)
+	offsets := make([]int, len(prefix))
+	for i := range offsets {
+		offsets[i] = -1
+	}
+
+	n.runes = slices.Insert(n.runes, 0, prefix...)
+	n.offsets = slices.Insert(n.offsets, 0, offsets...)
+}
@@ -0,0 +1,128 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestParseWithOptions(t *testing.T) {
+	type args struct {
+		s    string
+		opts vat.ParseOptions
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    vat.IDNumber
+		wantErr error
+	}{
+		{
+			name: "dots",
+			args: args{s: "DE 136.695.976"},
+			want: vat.IDNumber{CountryCode: "DE", Number: "136695976"},
+		},
+		{
+			name: "dashes",
+			args: args{s: "NL-8220 10690-B01"},
+			want: vat.IDNumber{CountryCode: "NL", Number: "822010690B01"},
+		},
+		{
+			name: "full-width characters, NBSP and ideographic space",
+			args: args{s: "ＤＥ\u00a0１３６\u3000６９５\u00a0９７６"},
+			want: vat.IDNumber{CountryCode: "DE", Number: "136695976"},
+		},
+		{
+			name: "english label",
+			args: args{s: "VAT No.: GB 980 7806 84"},
+			want: vat.IDNumber{CountryCode: "GB", Number: "980780684"},
+		},
+		{
+			name: "german label",
+			args: args{s: "USt-IdNr. DE136695976"},
+			want: vat.IDNumber{CountryCode: "DE", Number: "136695976"},
+		},
+		{
+			name: "french label",
+			args: args{s: "N° TVA : FR 40 303 265 045"},
+			want: vat.IDNumber{CountryCode: "FR", Number: "40303265045"},
+		},
+		{
+			name: "dutch label",
+			args: args{s: "btw: NL822010690B01"},
+			want: vat.IDNumber{CountryCode: "NL", Number: "822010690B01"},
+		},
+		{
+			name: "swiss number",
+			args: args{s: "CHE-116.281.710 MWST"},
+			want: vat.IDNumber{CountryCode: "CH", Number: "E116281710MWST"},
+		},
+		{
+			name: "default country",
+			args: args{s: "136 695 976", opts: vat.ParseOptions{DefaultCountry: "de"}},
+			want: vat.IDNumber{CountryCode: "DE", Number: "136695976"},
+		},
+		{
+			name: "default country with letters",
+			args: args{s: "U13585627", opts: vat.ParseOptions{DefaultCountry: "AT"}},
+			want: vat.IDNumber{CountryCode: "AT", Number: "U13585627"},
+		},
+		{
+			name: "default country as ISO code",
+			args: args{s: "094259216", opts: vat.ParseOptions{DefaultCountry: "GR"}},
+			want: vat.IDNumber{CountryCode: "EL", Number: "094259216"},
+		},
+		{
+			name: "default country ignored when prefix present",
+			args: args{s: "NL822010690B01", opts: vat.ParseOptions{DefaultCountry: "DE"}},
+			want: vat.IDNumber{CountryCode: "NL", Number: "822010690B01"},
+		},
+		{
+			name:    "strict keeps punctuation",
+			args:    args{s: "DE 136.695.976", opts: vat.ParseOptions{Strict: true}},
+			wantErr: vat.ErrInvalidFormat,
+		},
+		{
+			name:    "strict keeps labels",
+			args:    args{s: "VAT: DE136695976", opts: vat.ParseOptions{Strict: true}},
+			wantErr: vat.ErrInvalidCountryCode,
+		},
+		{
+			name: "strict with default country",
+			args: args{s: "136 695 976", opts: vat.ParseOptions{Strict: true, DefaultCountry: "DE"}},
+			want: vat.IDNumber{CountryCode: "DE", Number: "136695976"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vat.ParseWithOptions(tt.args.s, tt.args.opts)
+			assert.Equal(t, tt.want, got)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseWithOptions_Position(t *testing.T) {
+	_, err := vat.ParseWithOptions("VAT: NL 822.010.690/X01", vat.ParseOptions{})
+
+	var parseErr *vat.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, vat.ReasonInvalidCharacter, parseErr.Reason)
+	assert.Equal(t, 20, parseErr.Position)
+}
+
+func TestParseWithOptions_SkipCheckDigits(t *testing.T) {
+	_, err := vat.Parse("GB146295999727")
+	require.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+
+	got, err := vat.ParseWithOptions("GB146295999727", vat.ParseOptions{SkipCheckDigits: true})
+	require.NoError(t, err)
+	assert.Equal(t, vat.IDNumber{CountryCode: "GB", Number: "146295999727"}, got)
+
+	// The format is still checked.
+	_, err = vat.ParseWithOptions("GB14629599972", vat.ParseOptions{SkipCheckDigits: true})
+	require.ErrorIs(t, err, vat.ErrInvalidFormat)
+}
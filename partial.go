@@ -0,0 +1,307 @@
+package vat
+
+import (
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// CharClass describes the characters accepted at a position of a VAT number.
+type CharClass string
+
+const (
+	// CharClassNone means no further character is accepted.
+	CharClassNone CharClass = ""
+	// CharClassDigit means only digits are accepted.
+	CharClassDigit CharClass = "digit"
+	// CharClassLetter means only letters are accepted.
+	CharClassLetter CharClass = "letter"
+	// CharClassAlphanumeric means letters and digits are accepted.
+	CharClassAlphanumeric CharClass = "alphanumeric"
+)
+
+// PartialCheck tells how far an incomplete VAT number is from a valid one, see CheckPartial.
+type PartialCheck struct {
+	// CountryCode is the detected VAT prefix, empty until it is typed in full.
+	CountryCode string
+	// Viable reports whether more characters can still turn the input into a valid number.
+	// It is false once the input can't match the format of the country, or the prefix is unknown.
+	Viable bool
+	// Complete reports whether the input is already a valid number, check digits included.
+	// Longer numbers may still be possible, e.g. branch numbers.
+	Complete bool
+	// MinRemaining is the number of characters at least needed to complete the format.
+	MinRemaining int
+	// MaxRemaining is the number of characters that can be added at most, -1 if unbounded.
+	MaxRemaining int
+	// Next is the kind of character expected next.
+	Next CharClass
+	// Mask describes the format of the detected country like Country.Format, e.g. DE999999999.
+	Mask string
+	// Placeholder is an example number of the detected country.
+	Placeholder string
+}
+
+// CheckPartial checks a VAT number while it is being typed, e.g. to validate form inputs on every keystroke.
+// The input is cleaned up like ParseWithOptions does, and its format is checked as far as it goes.
+// Check digits can only be verified once the number is complete, see PartialCheck.Complete.
+func CheckPartial(input string) PartialCheck {
+	n := normalize(input, ParseOptions{})
+	if len(n.runes) < 2 {
+		return checkPartialPrefix(string(n.runes))
+	}
+
+	spec, ok := lookupSpec(string(n.runes[:2]))
+	if !ok {
+		return PartialCheck{}
+	}
+
+	check := PartialCheck{
+		CountryCode: spec.Code,
+		Mask:        spec.Format,
+	}
+	if len(spec.Examples) > 0 {
+		check.Placeholder = spec.Examples[0]
+	}
+
+	states := spec.prog.start()
+	for _, r := range n.runes[2:] {
+		states = spec.prog.step(states, r)
+	}
+
+	if len(states) == 0 {
+		return check
+	}
+
+	_, err := Parse(string(n.runes))
+	check.Complete = err == nil
+	check.MinRemaining, check.MaxRemaining = spec.prog.remaining(states)
+	check.Next = spec.prog.next(states)
+	// A complete format with wrong check digits can only become valid if it can grow.
+	check.Viable = check.Complete || check.MaxRemaining != 0
+
+	return check
+}
+
+// checkPartialPrefix checks an input too short to hold a VAT prefix.
+func checkPartialPrefix(prefix string) PartialCheck {
+	check := PartialCheck{MinRemaining: -1}
+
+	for _, spec := range allSpecs() {
+		codes := []string{spec.Code}
+		if spec.ISOCode != "" {
+			codes = append(codes, spec.ISOCode)
+		}
+
+		if !slices.ContainsFunc(codes, func(code string) bool { return strings.HasPrefix(code, prefix) }) {
+			continue
+		}
+
+		minRemaining, maxRemaining := spec.prog.remaining(spec.prog.start())
+		minRemaining += 2 - len(prefix)
+		if !check.Viable || minRemaining < check.MinRemaining {
+			check.MinRemaining = minRemaining
+		}
+
+		if maxRemaining < 0 || check.MaxRemaining < 0 {
+			check.MaxRemaining = -1
+		} else {
+			check.MaxRemaining = max(check.MaxRemaining, maxRemaining+2-len(prefix))
+		}
+
+		check.Viable = true
+	}
+
+	if !check.Viable {
+		return PartialCheck{}
+	}
+
+	check.Next = CharClassLetter
+
+	return check
+}
+
+// partialProg runs the compiled pattern of a country on incomplete input.
+type partialProg struct {
+	*syntax.Prog
+}
+
+func compilePartial(pattern string) (partialProg, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return partialProg{}, err
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return partialProg{}, err
+	}
+
+	return partialProg{prog}, nil
+}
+
+// closure adds pc and the instructions reachable from it without consuming a rune to states.
+func (p partialProg) closure(states []uint32, pc uint32) []uint32 {
+	if slices.Contains(states, pc) {
+		return states
+	}
+
+	states = append(states, pc)
+
+	inst := p.Inst[pc]
+	switch inst.Op {
+	case syntax.InstAlt, syntax.InstAltMatch:
+		states = p.closure(states, inst.Out)
+		states = p.closure(states, inst.Arg)
+	case syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstNop:
+		// Patterns are anchored, so empty width assertions hold where they appear.
+		states = p.closure(states, inst.Out)
+	case syntax.InstMatch, syntax.InstFail, syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny,
+		syntax.InstRuneAnyNotNL:
+	}
+
+	return states
+}
+
+func (p partialProg) start() []uint32 {
+	return p.closure(nil, uint32(p.Start))
+}
+
+// step returns the states reached from states by consuming r.
+func (p partialProg) step(states []uint32, r rune) []uint32 {
+	var next []uint32
+	for _, pc := range states {
+		inst := p.Inst[pc]
+		if isRuneInst(inst.Op) && matchesRune(inst, r) {
+			next = p.closure(next, inst.Out)
+		}
+	}
+
+	return next
+}
+
+func isRuneInst(op syntax.InstOp) bool {
+	return op == syntax.InstRune || op == syntax.InstRune1 || op == syntax.InstRuneAny || op == syntax.InstRuneAnyNotNL
+}
+
+// matchesRune reports whether the rune instruction inst accepts r.
+func matchesRune(inst syntax.Inst, r rune) bool {
+	switch inst.Op {
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	case syntax.InstRune, syntax.InstRune1:
+		return inst.MatchRune(r)
+	case syntax.InstAlt, syntax.InstAltMatch, syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstMatch,
+		syntax.InstFail, syntax.InstNop:
+		return false
+	default:
+		return false
+	}
+}
+
+// remaining returns the least and the most runes needed to reach a match from states.
+// The maximum is -1 if it is unbounded, and both are -1 if no match can be reached.
+func (p partialProg) remaining(states []uint32) (int, int) {
+	minRemaining, maxRemaining := -1, -1
+	longest := make(map[uint32]int)
+	visiting := make(map[uint32]bool)
+	unbounded := false
+
+	// depth first search of the longest path, breadth first of the shortest one
+	var longestFrom func(pc uint32) int
+	longestFrom = func(pc uint32) int {
+		if l, ok := longest[pc]; ok {
+			return l
+		}
+
+		if visiting[pc] {
+			unbounded = true
+
+			return -1
+		}
+
+		visiting[pc] = true
+		defer delete(visiting, pc)
+
+		l := -1
+		inst := p.Inst[pc]
+		switch {
+		case inst.Op == syntax.InstMatch:
+			l = 0
+		case isRuneInst(inst.Op):
+			for _, next := range p.closure(nil, inst.Out) {
+				if n := longestFrom(next); n >= 0 {
+					l = max(l, n+1)
+				}
+			}
+		}
+
+		longest[pc] = l
+
+		return l
+	}
+
+	for _, pc := range states {
+		maxRemaining = max(maxRemaining, longestFrom(pc))
+	}
+
+	for steps, current := 0, states; len(current) > 0 && steps <= len(p.Inst); steps++ {
+		if slices.ContainsFunc(current, func(pc uint32) bool { return p.Inst[pc].Op == syntax.InstMatch }) {
+			minRemaining = steps
+
+			break
+		}
+
+		var next []uint32
+		for _, pc := range current {
+			if isRuneInst(p.Inst[pc].Op) {
+				next = p.closure(next, p.Inst[pc].Out)
+			}
+		}
+
+		current = next
+	}
+
+	if unbounded && maxRemaining >= 0 {
+		maxRemaining = -1
+	}
+
+	return minRemaining, maxRemaining
+}
+
+// next returns the kind of runes accepted from states.
+func (p partialProg) next(states []uint32) CharClass {
+	digits, letters := false, false
+	for _, pc := range states {
+		inst := p.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRune1:
+			digits = digits || unicode.IsDigit(inst.Rune[0])
+			letters = letters || unicode.IsLetter(inst.Rune[0])
+		case syntax.InstRune:
+			for i := 0; i+1 < len(inst.Rune); i += 2 {
+				lo, hi := inst.Rune[i], inst.Rune[i+1]
+				digits = digits || lo <= '9' && hi >= '0'
+				letters = letters || lo <= 'Z' && hi >= 'A' || lo <= 'z' && hi >= 'a'
+			}
+		case syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			digits, letters = true, true
+		case syntax.InstAlt, syntax.InstAltMatch, syntax.InstCapture, syntax.InstEmptyWidth, syntax.InstMatch,
+			syntax.InstFail, syntax.InstNop:
+		}
+	}
+
+	switch {
+	case digits && letters:
+		return CharClassAlphanumeric
+	case digits:
+		return CharClassDigit
+	case letters:
+		return CharClassLetter
+	default:
+		return CharClassNone
+	}
+}
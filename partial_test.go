@@ -0,0 +1,117 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestCheckPartial(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  vat.PartialCheck
+	}{
+		{
+			name:  "start of a prefix",
+			input: "D",
+			want:  vat.PartialCheck{Viable: true, MinRemaining: 9, MaxRemaining: 10, Next: vat.CharClassLetter},
+		},
+		{
+			name:  "unknown prefix",
+			input: "J",
+			want:  vat.PartialCheck{},
+		},
+		{
+			name:  "prefix only",
+			input: "DE",
+			want: vat.PartialCheck{
+				CountryCode: "DE", Viable: true, MinRemaining: 9, MaxRemaining: 9, Next: vat.CharClassDigit,
+				Mask: "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "letter expected",
+			input: "NL822010690",
+			want: vat.PartialCheck{
+				CountryCode: "NL", Viable: true, MinRemaining: 3, MaxRemaining: 3, Next: vat.CharClassLetter,
+				Mask: "NL999999999B99", Placeholder: "NL822010690B01",
+			},
+		},
+		{
+			name:  "separators and labels",
+			input: "USt-IdNr.: DE 136.695",
+			want: vat.PartialCheck{
+				CountryCode: "DE", Viable: true, MinRemaining: 3, MaxRemaining: 3, Next: vat.CharClassDigit,
+				Mask: "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "complete",
+			input: "DE136695976",
+			want: vat.PartialCheck{
+				CountryCode: "DE", Viable: true, Complete: true,
+				Mask: "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "complete with wrong check digits",
+			input: "DE136695977",
+			want: vat.PartialCheck{
+				CountryCode: "DE",
+				Mask:        "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "too long",
+			input: "DE1366959760",
+			want: vat.PartialCheck{
+				CountryCode: "DE",
+				Mask:        "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "wrong character",
+			input: "DE13A",
+			want: vat.PartialCheck{
+				CountryCode: "DE",
+				Mask:        "DE999999999", Placeholder: "DE136695976",
+			},
+		},
+		{
+			name:  "wrong check digits but longer numbers possible",
+			input: "CZ12345678",
+			want: vat.PartialCheck{
+				CountryCode: "CZ", Viable: true, MaxRemaining: 2, Next: vat.CharClassDigit,
+				Mask: "CZ99999999, CZ999999999 or CZ9999999999", Placeholder: "CZ25123891",
+			},
+		},
+		{
+			name:  "optional letter",
+			input: "AT",
+			want: vat.PartialCheck{
+				CountryCode: "AT", Viable: true, MinRemaining: 8, MaxRemaining: 9, Next: vat.CharClassAlphanumeric,
+				Mask: "ATU99999999 or AT99999999", Placeholder: "ATU13585627",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, vat.CheckPartial(tt.input))
+		})
+	}
+}
+
+func TestCheckPartialExamples(t *testing.T) {
+	for _, c := range vat.Countries() {
+		for _, example := range c.Examples {
+			for i := range len(example) {
+				assert.True(t, vat.CheckPartial(example[:i]).Viable, "%s[:%d]", example, i)
+			}
+
+			assert.True(t, vat.CheckPartial(example).Complete, example)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package vat
+
+import "slices"
+
+// Provider identifies a registry service that can verify VAT numbers.
+type Provider string
+
+const (
+	// ProviderVIES is the EU VAT Information Exchange System.
+	ProviderVIES Provider = "vies"
+	// ProviderHMRC is the UK HMRC VAT registered companies API.
+	ProviderHMRC Provider = "hmrc"
+	// ProviderABR is the Australian Business Register.
+	ProviderABR Provider = "abr"
+)
+
+// ProvidersFor returns the services able to verify VAT numbers of the given country.
+// The first provider is the default one. It returns nil for countries no service can verify.
+func ProvidersFor(countryCode string) []Provider {
+	spec, ok := lookupSpec(countryCode)
+	if !ok {
+		return nil
+	}
+
+	return slices.Clone(spec.Providers)
+}
+
+// defaultCountries returns the countries whose default provider is p, sorted.
+func defaultCountries(p Provider) []string {
+	var countries []string
+	for _, spec := range allSpecs() {
+		if len(spec.Providers) > 0 && spec.Providers[0] == p {
+			countries = append(countries, spec.Code)
+		}
+	}
+
+	slices.Sort(countries)
+
+	return countries
+}
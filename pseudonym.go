@@ -0,0 +1,81 @@
+package vat
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// minPseudonymSecretLength is the minimum length of a PseudonymKey secret, in bytes.
+const minPseudonymSecretLength = 16
+
+// PseudonymKey is a secret used to pseudonymize VAT numbers, see Pseudonymize.
+type PseudonymKey struct {
+	// ID identifies the key in the tokens it produced, so keys can be rotated.
+	// It must be non-empty and can't contain a dot.
+	ID string
+	// Secret is the HMAC key, at least 16 bytes long. Use a random one and keep it out of the data warehouse.
+	Secret []byte
+}
+
+func (k PseudonymKey) validate() error {
+	if k.ID == "" || strings.Contains(k.ID, ".") {
+		return fmt.Errorf("%w: key ID %q must be non-empty and can't contain a dot", ErrInvalidPseudonymKey, k.ID)
+	}
+
+	if len(k.Secret) < minPseudonymSecretLength {
+		return fmt.Errorf("%w: secret of key %s must be at least %d bytes long",
+			ErrInvalidPseudonymKey, k.ID, minPseudonymSecretLength)
+	}
+
+	return nil
+}
+
+// Pseudonymize returns a stable token for id that can't be reversed without key, to join datasets on VAT numbers
+// without sharing them. Numbers that are Equal have the same token, e.g. GR and EL or XI and GB numbers.
+//
+// The token is the key ID followed by a dot and the unpadded base64url HMAC-SHA256 of the number,
+// e.g. 2024.Xj3…; use PseudonymKeyID to tell which key produced a token when rotating keys.
+func Pseudonymize(key PseudonymKey, id IDNumber) (string, error) {
+	if err := key.validate(); err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(id.equalityKey().String()))
+
+	return key.ID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// PseudonymKeyID returns the ID of the key a token returned by Pseudonymize was produced with.
+func PseudonymKeyID(token string) (string, bool) {
+	keyID, _, found := strings.Cut(token, ".")
+
+	return keyID, found && keyID != ""
+}
+
+// MatchPseudonym reports whether token was produced by Pseudonymize for id with one of keys.
+// The key is picked by the ID in the token, which lets tokens of retired keys still be matched.
+func MatchPseudonym(token string, id IDNumber, keys ...PseudonymKey) bool {
+	keyID, ok := PseudonymKeyID(token)
+	if !ok {
+		return false
+	}
+
+	for _, key := range keys {
+		if key.ID != keyID {
+			continue
+		}
+
+		want, err := Pseudonymize(key, id)
+		if err != nil {
+			return false
+		}
+
+		return hmac.Equal([]byte(token), []byte(want))
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestPseudonymize(t *testing.T) {
+	key2024 := vat.PseudonymKey{ID: "2024", Secret: []byte("0123456789abcdef")}
+	key2025 := vat.PseudonymKey{ID: "2025", Secret: []byte("fedcba9876543210")}
+
+	token, err := vat.Pseudonymize(key2024, vat.MustParse("EL094259216"))
+	require.NoError(t, err)
+	assert.Regexp(t, `^2024\.[A-Za-z0-9_-]{43}$`, token)
+	assert.NotContains(t, token, "094259216")
+
+	// Aliases collapse to the same token.
+	alias, err := vat.Pseudonymize(key2024, vat.MustParse("GR094259216"))
+	require.NoError(t, err)
+	assert.Equal(t, token, alias)
+
+	other, err := vat.Pseudonymize(key2024, vat.MustParse("DE136695976"))
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	rotated, err := vat.Pseudonymize(key2025, vat.MustParse("EL094259216"))
+	require.NoError(t, err)
+	assert.NotEqual(t, token, rotated)
+
+	keyID, ok := vat.PseudonymKeyID(rotated)
+	require.True(t, ok)
+	assert.Equal(t, "2025", keyID)
+
+	assert.True(t, vat.MatchPseudonym(token, vat.MustParse("GR094259216"), key2025, key2024))
+	assert.True(t, vat.MatchPseudonym(rotated, vat.MustParse("GR094259216"), key2025, key2024))
+	assert.False(t, vat.MatchPseudonym(token, vat.MustParse("DE136695976"), key2025, key2024))
+	assert.False(t, vat.MatchPseudonym(token, vat.MustParse("GR094259216"), key2025))
+}
+
+func TestPseudonymize_Literal(t *testing.T) {
+	key := vat.PseudonymKey{ID: "2024", Secret: []byte("0123456789abcdef")}
+
+	parsed, err := vat.Pseudonymize(key, vat.MustParse("US52-1234567"))
+	require.NoError(t, err)
+
+	// A number built without its scheme gets the same token as the parsed one.
+	literal, err := vat.Pseudonymize(key, vat.IDNumber{CountryCode: "US", Number: "521234567"})
+	require.NoError(t, err)
+	assert.Equal(t, parsed, literal)
+	assert.True(t, vat.MatchPseudonym(parsed, vat.IDNumber{CountryCode: "us", Number: "52-1234567"}, key))
+}
+
+func TestPseudonymize_InvalidKey(t *testing.T) {
+	id := vat.MustParse("DE136695976")
+
+	_, err := vat.Pseudonymize(vat.PseudonymKey{Secret: []byte("0123456789abcdef")}, id)
+	require.ErrorIs(t, err, vat.ErrInvalidPseudonymKey)
+
+	_, err = vat.Pseudonymize(vat.PseudonymKey{ID: "v1.2", Secret: []byte("0123456789abcdef")}, id)
+	require.ErrorIs(t, err, vat.ErrInvalidPseudonymKey)
+
+	_, err = vat.Pseudonymize(vat.PseudonymKey{ID: "2024", Secret: []byte("short")}, id)
+	require.ErrorIs(t, err, vat.ErrInvalidPseudonymKey)
+}
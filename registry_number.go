@@ -0,0 +1,161 @@
+package vat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Registry identifies a national company register whose numbers are embedded in VAT numbers.
+type Registry string
+
+const (
+	// RegistrySIREN is the French Système d'identification du répertoire des entreprises.
+	RegistrySIREN Registry = "siren"
+	// RegistryKBO is the Belgian Crossroads Bank for Enterprises (KBO/BCE).
+	RegistryKBO Registry = "kbo"
+	// RegistryCVR is the Danish Central Business Register.
+	RegistryCVR Registry = "cvr"
+	// RegistryPartitaIVA is the Italian VAT register, whose numbers identify businesses.
+	RegistryPartitaIVA Registry = "partita_iva"
+	// RegistryUID is the Swiss business identification number register.
+	RegistryUID Registry = "uid"
+	// RegistryOrgNr is the Norwegian Central Coordinating Register for Legal Entities (Enhetsregisteret),
+	// which issues organisation numbers.
+	RegistryOrgNr Registry = "orgnr"
+)
+
+const (
+	sirenLength = 9
+	orgNrLength = 9
+)
+
+// RegistryNumber is the number of an entity in a national company register.
+type RegistryNumber struct {
+	Registry Registry
+	// Number is the compact form of the number, without separators.
+	Number string
+}
+
+func (r RegistryNumber) String() string {
+	return r.Number
+}
+
+// registryPrefixes maps the registers to the VAT prefix of their country.
+//
+//nolint:gochecknoglobals // This is a constant map of registers to country codes.
+var registryPrefixes = map[Registry]string{
+	RegistrySIREN:      "FR",
+	RegistryKBO:        "BE",
+	RegistryCVR:        "DK",
+	RegistryPartitaIVA: "IT",
+	RegistryUID:        "CH",
+	RegistryOrgNr:      "NO",
+}
+
+// RegistryNumber returns the national company register number embedded in the VAT number,
+// e.g. the SIREN of French numbers. It reports false for countries whose VAT numbers don't embed one,
+// and for Swiss numbers whose UID check digit doesn't match, which Parse accepts.
+// The number is expected to come from Parse.
+func (id IDNumber) RegistryNumber() (RegistryNumber, bool) {
+	id = id.Canonical()
+
+	switch id.CountryCode {
+	case "FR":
+		// The key is followed by the SIREN
+		return RegistryNumber{Registry: RegistrySIREN, Number: id.Number[2:]}, true
+	case "BE":
+		return RegistryNumber{Registry: RegistryKBO, Number: id.Number}, true
+	case "DK":
+		return RegistryNumber{Registry: RegistryCVR, Number: id.Number}, true
+	case "IT":
+		return RegistryNumber{Registry: RegistryPartitaIVA, Number: id.Number}, true
+	case "CH":
+		if !validCH(id.Number) {
+			return RegistryNumber{}, false
+		}
+
+		return RegistryNumber{Registry: RegistryUID, Number: id.String()}, true
+	case "NO":
+		// The organisation number is followed by MVA
+		return RegistryNumber{Registry: RegistryOrgNr, Number: id.Number[:orgNrLength]}, true
+	default:
+		return RegistryNumber{}, false
+	}
+}
+
+// FromRegistryNumber returns the VAT number embedding a national company register number.
+// Separators like spaces, dots and dashes are removed from the number first.
+// Errors are of type *ParseError, as returned by Parse for the resulting VAT number,
+// except for unknown registries, which match ErrInvalidCountryCode.
+// The check digit of Swiss UIDs is verified here, as Parse does not verify it.
+func FromRegistryNumber(r RegistryNumber) (IDNumber, error) {
+	countryCode, ok := registryPrefixes[r.Registry]
+	if !ok {
+		return IDNumber{}, fmt.Errorf("%w: unknown registry %q", ErrInvalidCountryCode, r.Registry)
+	}
+
+	number := strings.Map(func(c rune) rune {
+		if isSeparator(c) {
+			return -1
+		}
+
+		return c
+	}, strings.ToUpper(r.Number))
+
+	switch r.Registry {
+	case RegistrySIREN:
+		if len(number) == sirenLength && isDigits(number) {
+			number = fmt.Sprintf("%02d%s", atoi(number+"12")%97, number)
+		}
+	case RegistryUID:
+		number = strings.TrimPrefix(number, countryCode)
+	case RegistryKBO, RegistryCVR, RegistryPartitaIVA, RegistryOrgNr:
+		// The VAT number is the registry number, Canonical adds the MVA suffix of Norwegian ones
+	}
+
+	id, err := Parse(countryCode + number)
+	if err != nil {
+		return IDNumber{}, err
+	}
+
+	if r.Registry == RegistryUID && !validCH(id.Number) {
+		return IDNumber{}, &ParseError{
+			Input:       countryCode + number,
+			CountryCode: countryCode,
+			Reason:      ReasonCheckDigits,
+			Position:    -1,
+		}
+	}
+
+	return id.Canonical(), nil
+}
+
+// FromSIREN returns the French VAT number of a SIREN, computing its key.
+func FromSIREN(siren string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistrySIREN, Number: siren})
+}
+
+// FromKBO returns the Belgian VAT number of an enterprise number, e.g. 0403.019.261.
+func FromKBO(enterpriseNumber string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistryKBO, Number: enterpriseNumber})
+}
+
+// FromCVR returns the Danish VAT number of a CVR number.
+func FromCVR(cvr string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistryCVR, Number: cvr})
+}
+
+// FromPartitaIVA returns the Italian VAT number of a partita IVA.
+func FromPartitaIVA(partitaIVA string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistryPartitaIVA, Number: partitaIVA})
+}
+
+// FromUID returns the Swiss VAT number of a UID, e.g. CHE-116.281.710.
+func FromUID(uid string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistryUID, Number: uid})
+}
+
+// FromOrgNr returns the Norwegian VAT number of an organisation number, e.g. 974 760 673.
+func FromOrgNr(orgNr string) (IDNumber, error) {
+	return FromRegistryNumber(RegistryNumber{Registry: RegistryOrgNr, Number: orgNr})
+}
@@ -0,0 +1,76 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_RegistryNumber(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   vat.RegistryNumber
+		wantOK bool
+	}{
+		{"FR40303265045", vat.RegistryNumber{Registry: vat.RegistrySIREN, Number: "303265045"}, true},
+		{"BE403019261", vat.RegistryNumber{Registry: vat.RegistryKBO, Number: "0403019261"}, true},
+		{"DK13585628", vat.RegistryNumber{Registry: vat.RegistryCVR, Number: "13585628"}, true},
+		{"IT00743110157", vat.RegistryNumber{Registry: vat.RegistryPartitaIVA, Number: "00743110157"}, true},
+		{"CHE-116.281.710 MWST", vat.RegistryNumber{Registry: vat.RegistryUID, Number: "CHE116281710"}, true},
+		{"CHE123456789", vat.RegistryNumber{}, false},
+		{"NO974760673", vat.RegistryNumber{Registry: vat.RegistryOrgNr, Number: "974760673"}, true},
+		{"DE136695976", vat.RegistryNumber{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := vat.MustParse(tt.input).RegistryNumber()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromRegistryNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(string) (vat.IDNumber, error)
+		number  string
+		want    string
+		wantErr error
+	}{
+		{name: "SIREN", fn: vat.FromSIREN, number: "303 265 045", want: "FR40303265045"},
+		{name: "SIREN with bad check digit", fn: vat.FromSIREN, number: "303265046", wantErr: vat.ErrInvalidCheckDigits},
+		{name: "KBO", fn: vat.FromKBO, number: "0403.019.261", want: "BE0403019261"},
+		{name: "CVR", fn: vat.FromCVR, number: "13585628", want: "DK13585628"},
+		{name: "partita IVA", fn: vat.FromPartitaIVA, number: "00743110157", want: "IT00743110157"},
+		{name: "UID", fn: vat.FromUID, number: "CHE-116.281.710", want: "CHE116281710"},
+		{name: "UID with bad check digit", fn: vat.FromUID, number: "CHE-116.281.711", wantErr: vat.ErrInvalidCheckDigits},
+		{name: "organisation number", fn: vat.FromOrgNr, number: "974 760 673", want: "NO974760673MVA"},
+		{name: "too short", fn: vat.FromCVR, number: "1358562", wantErr: vat.ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.number)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+
+			registryNumber, ok := got.RegistryNumber()
+			require.True(t, ok)
+			roundTrip, err := vat.FromRegistryNumber(registryNumber)
+			require.NoError(t, err)
+			assert.Equal(t, got, roundTrip)
+		})
+	}
+
+	_, err := vat.FromRegistryNumber(vat.RegistryNumber{Registry: "kvk", Number: "12345678"})
+	require.ErrorIs(t, err, vat.ErrInvalidCountryCode)
+}
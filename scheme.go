@@ -0,0 +1,65 @@
+package vat
+
+import "strings"
+
+// Scheme tells which kind of tax identifier an IDNumber holds, so that e.g. a US EIN isn't taken for a VAT number.
+type Scheme string
+
+const (
+	// SchemeVAT is a VAT number, or the number used in its place like the Swiss UID or the Australian ABN.
+	// It is the zero value, as most numbers Parse accepts are VAT numbers.
+	SchemeVAT Scheme = ""
+	// SchemeBN is a Canadian business number, optionally followed by a program account other than GST/HST,
+	// like RC0001 for corporate income tax.
+	SchemeBN Scheme = "ca_bn"
+	// SchemeGSTHST is a Canadian GST/HST account: the business number followed by RT and the reference number.
+	SchemeGSTHST Scheme = "ca_gst_hst"
+	// SchemeQST is a Quebec sales tax number: 10 digits followed by TQ and the file number.
+	SchemeQST Scheme = "ca_qst"
+	// SchemeEIN is a US employer identification number.
+	SchemeEIN Scheme = "us_ein"
+	// SchemeRFC is a Mexican Registro Federal de Contribuyentes.
+	SchemeRFC Scheme = "mx_rfc"
+)
+
+const (
+	bnLength  = 9
+	qstLength = 16
+)
+
+// schemeCountries maps the schemes other than SchemeVAT to the country code of their numbers.
+//
+//nolint:gochecknoglobals // This is a constant map of schemes to their country.
+var schemeCountries = map[Scheme]string{
+	SchemeBN:     "CA",
+	SchemeGSTHST: "CA",
+	SchemeQST:    "CA",
+	SchemeEIN:    "US",
+	SchemeRFC:    "MX",
+}
+
+// CountryCode returns the country code of the numbers of the scheme, or an empty string for SchemeVAT.
+func (s Scheme) CountryCode() string {
+	return schemeCountries[s]
+}
+
+// schemeOf returns the scheme of a number, expected to match the pattern of its country.
+func schemeOf(countryCode, number string) Scheme {
+	switch countryCode {
+	case "CA":
+		switch {
+		case len(number) == qstLength:
+			return SchemeQST
+		case len(number) > bnLength && strings.HasPrefix(number[bnLength:], "RT"):
+			return SchemeGSTHST
+		default:
+			return SchemeBN
+		}
+	case "US":
+		return SchemeEIN
+	case "MX":
+		return SchemeRFC
+	default:
+		return SchemeVAT
+	}
+}
@@ -0,0 +1,96 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestParse_Scheme(t *testing.T) {
+	tests := []struct {
+		input string
+		want  vat.Scheme
+	}{
+		{input: "CA123456782", want: vat.SchemeBN},
+		{input: "CA123456782RC0001", want: vat.SchemeBN},
+		{input: "CA123456782RT0001", want: vat.SchemeGSTHST},
+		{input: "CA1234567890TQ0001", want: vat.SchemeQST},
+		{input: "US52-1234567", want: vat.SchemeEIN},
+		{input: "MXGODE561231GR8", want: vat.SchemeRFC},
+		{input: "NL822010690B01", want: vat.SchemeVAT},
+		{input: "AU51824753556", want: vat.SchemeVAT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := vat.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Scheme)
+		})
+	}
+}
+
+func TestParseWithOptions_Scheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		scheme     vat.Scheme
+		want       vat.IDNumber
+		wantReason vat.ParseErrorReason
+	}{
+		{
+			name:   "GST/HST account without prefix",
+			input:  "GST/HST: 123456782 RT 0001",
+			scheme: vat.SchemeGSTHST,
+			want:   vat.IDNumber{CountryCode: "CA", Number: "123456782RT0001", Scheme: vat.SchemeGSTHST},
+		},
+		{
+			name:   "EIN with prefix",
+			input:  "US 52-1234567",
+			scheme: vat.SchemeEIN,
+			want:   vat.IDNumber{CountryCode: "US", Number: "521234567", Scheme: vat.SchemeEIN},
+		},
+		{
+			name:   "RFC starting with a known prefix",
+			input:  "RFC: DEAA-800101-XX4",
+			scheme: vat.SchemeRFC,
+			want:   vat.IDNumber{CountryCode: "MX", Number: "DEAA800101XX4", Scheme: vat.SchemeRFC},
+		},
+		{
+			name:       "business number for GST/HST",
+			input:      "123456782",
+			scheme:     vat.SchemeGSTHST,
+			wantReason: vat.ReasonWrongScheme,
+		},
+		{
+			name:       "GST/HST account for QST",
+			input:      "CA123456782RT0001",
+			scheme:     vat.SchemeQST,
+			wantReason: vat.ReasonWrongScheme,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := vat.ParseWithOptions(tt.input, vat.ParseOptions{Scheme: tt.scheme})
+			if tt.wantReason != "" {
+				var parseErr *vat.ParseError
+				require.ErrorAs(t, err, &parseErr)
+				assert.Equal(t, tt.wantReason, parseErr.Reason)
+				require.ErrorIs(t, err, vat.ErrInvalidFormat)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheme_CountryCode(t *testing.T) {
+	assert.Equal(t, "CA", vat.SchemeQST.CountryCode())
+	assert.Equal(t, "US", vat.SchemeEIN.CountryCode())
+	assert.Empty(t, vat.SchemeVAT.CountryCode())
+}
@@ -0,0 +1,123 @@
+package vat
+
+import "slices"
+
+const maxSuggestions = 5
+
+// prefixTypos maps country codes people commonly use instead of the VAT prefix.
+//
+//nolint:gochecknoglobals // This is a constant map of mistyped country codes.
+var prefixTypos = map[string]string{
+	"UK": "GB",
+}
+
+// confusables maps characters to those they are commonly mistaken for when typed or read from paper.
+//
+//nolint:gochecknoglobals // This is a constant map of confusable characters.
+var confusables = map[rune][]rune{
+	'O': {'0'}, '0': {'O'},
+	'I': {'1'}, 'L': {'1'}, '1': {'I', 'L'},
+	'B': {'8'}, '8': {'B'},
+	'S': {'5'}, '5': {'S'},
+	'Z': {'2'}, '2': {'Z'},
+}
+
+// Suggest returns corrections of a VAT number rejected by Parse, most likely first, e.g. to ask
+// "Did you mean ...?". It tries a single confusable character (O and 0, I and 1, B and 8, ...),
+// a single swap of adjacent characters, a single extra or missing digit, and aliases like GR or UK for the prefix.
+// Every suggestion passes Parse, check digits included, and is in its canonical form.
+// It returns nil if the input is valid or no correction is found.
+func Suggest(input string) []IDNumber {
+	n := normalize(input, ParseOptions{})
+	if len(n.runes) < idNumberMinLength {
+		return nil
+	}
+
+	if _, err := Parse(string(n.runes)); err == nil {
+		return nil
+	}
+
+	prefix := string(n.runes[:2])
+	if fixed, ok := prefixTypos[prefix]; ok {
+		prefix = fixed
+	}
+
+	prefix = canonicalCountryCode(prefix)
+	number := n.runes[2:]
+
+	var suggestions []IDNumber
+	try := func(candidate []rune) bool {
+		id, err := Parse(prefix + string(candidate))
+		if err == nil && !slices.Contains(suggestions, id.Canonical()) {
+			suggestions = append(suggestions, id.Canonical())
+		}
+
+		return len(suggestions) == maxSuggestions
+	}
+
+	for _, edits := range []func([]rune) [][]rune{
+		func(number []rune) [][]rune { return [][]rune{number} },
+		replaceConfusables,
+		swapAdjacent,
+		deleteOne,
+		insertDigit,
+	} {
+		for _, candidate := range edits(number) {
+			if try(candidate) {
+				return suggestions
+			}
+		}
+	}
+
+	return suggestions
+}
+
+func replaceConfusables(number []rune) [][]rune {
+	var candidates [][]rune
+	for i, r := range number {
+		for _, replacement := range confusables[r] {
+			candidate := slices.Clone(number)
+			candidate[i] = replacement
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+func swapAdjacent(number []rune) [][]rune {
+	var candidates [][]rune
+	for i := range len(number) - 1 {
+		if number[i] == number[i+1] {
+			continue
+		}
+
+		candidate := slices.Clone(number)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+func deleteOne(number []rune) [][]rune {
+	candidates := make([][]rune, 0, len(number))
+	for i := range number {
+		candidates = append(candidates, slices.Delete(slices.Clone(number), i, i+1))
+	}
+
+	return candidates
+}
+
+func insertDigit(number []rune) [][]rune {
+	const digits = 10
+
+	candidates := make([][]rune, 0, digits*(len(number)+1))
+	for i := range len(number) + 1 {
+		for d := '0'; d <= '9'; d++ {
+			candidates = append(candidates, slices.Insert(slices.Clone(number), i, d))
+		}
+	}
+
+	return candidates
+}
@@ -0,0 +1,50 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "letter O for zero", input: "NL822O10690B01", want: "NL822010690B01"},
+		{name: "letter I for one", input: "DEI36695976", want: "DE136695976"},
+		{name: "eight for B", input: "NL822010690801", want: "NL822010690B01"},
+		{name: "swapped digits", input: "DE163695976", want: "DE136695976"},
+		{name: "extra digit", input: "DE1366959766", want: "DE136695976"},
+		{name: "missing digit", input: "DE13669576", want: "DE136695976"},
+		{name: "UK prefix", input: "UK980780684", want: "GB980780684"},
+		{name: "GR prefix with a typo", input: "GR094259261", want: "EL094259216"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := vat.Suggest(tt.input)
+			assert.LessOrEqual(t, len(got), 5)
+			if assert.NotEmpty(t, got) {
+				assert.Contains(t, got, vat.MustParse(tt.want))
+			}
+
+			for _, id := range got {
+				_, err := vat.Parse(id.String())
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Nil(t, vat.Suggest("DE136695976"))
+	assert.Nil(t, vat.Suggest("X"))
+	assert.Nil(t, vat.Suggest("QQ123456789"))
+}
+
+func TestSuggest_Ranking(t *testing.T) {
+	// A confusable character is more likely than a missing digit.
+	got := vat.Suggest("NL822O10690B01")
+	assert.Equal(t, vat.MustParse("NL822010690B01"), got[0])
+}
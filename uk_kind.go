@@ -0,0 +1,42 @@
+package vat
+
+// UKKind classifies UK (GB and XI) VAT numbers.
+type UKKind string
+
+const (
+	// UKKindNone is returned for numbers that are not UK VAT numbers.
+	UKKindNone UKKind = ""
+	// UKKindStandard is a 9 digit VAT registration number.
+	UKKindStandard UKKind = "standard"
+	// UKKindBranchTrader is a standard number followed by a 3 digit branch code.
+	UKKindBranchTrader UKKind = "branch_trader"
+	// UKKindGovernmentDepartment is a GD number between GD000 and GD499.
+	UKKindGovernmentDepartment UKKind = "government_department"
+	// UKKindHealthAuthority is an HA number between HA500 and HA999.
+	UKKindHealthAuthority UKKind = "health_authority"
+)
+
+const (
+	ukStandardLength     = 9
+	ukBranchTraderLength = 12
+)
+
+// UKKind returns the kind of a UK VAT number, or UKKindNone for other countries.
+func (id IDNumber) UKKind() UKKind {
+	if id.CountryCode != "GB" && id.CountryCode != "XI" {
+		return UKKindNone
+	}
+
+	switch {
+	case len(id.Number) > 2 && id.Number[:2] == "GD":
+		return UKKindGovernmentDepartment
+	case len(id.Number) > 2 && id.Number[:2] == "HA":
+		return UKKindHealthAuthority
+	case len(id.Number) == ukStandardLength:
+		return UKKindStandard
+	case len(id.Number) == ukBranchTraderLength:
+		return UKKindBranchTrader
+	default:
+		return UKKindNone
+	}
+}
@@ -0,0 +1,42 @@
+package vat_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/creativefabrica/vat"
+)
+
+func TestIDNumber_UKKind(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want vat.UKKind
+	}{
+		{name: "standard", id: "GB980780684", want: vat.UKKindStandard},
+		{name: "standard mod 9755", id: "GB980780629", want: vat.UKKindStandard},
+		{name: "branch trader", id: "GB980780684001", want: vat.UKKindBranchTrader},
+		{name: "government department", id: "GBGD001", want: vat.UKKindGovernmentDepartment},
+		{name: "health authority", id: "GBHA599", want: vat.UKKindHealthAuthority},
+		{name: "northern ireland", id: "XI980780684", want: vat.UKKindStandard},
+		{name: "not uk", id: "NL822010690B01", want: vat.UKKindNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, vat.MustParse(tt.id).UKKind())
+		})
+	}
+}
+
+func TestParse_UK(t *testing.T) {
+	for _, s := range []string{"GB980780685", "GB980780685001", "GB980780628"} {
+		_, err := vat.Parse(s)
+		assert.ErrorIs(t, err, vat.ErrInvalidCheckDigits, s)
+	}
+
+	for _, s := range []string{"GBGD500", "GBHA499", "GBGD01", "GBHA5000", "GBXX123"} {
+		_, err := vat.Parse(s)
+		assert.ErrorIs(t, err, vat.ErrInvalidFormat, s)
+	}
+}
@@ -1,3 +1,8 @@
+// Package ukvat verifies GB VAT numbers with the HMRC VAT registered companies API.
+//
+// The test numbers of the HMRC sandbox, e.g. GB146295999727, don't carry valid check digits, so vat.Parse rejects them.
+// Parse them with vat.ParseOptions{SkipCheckDigits: true, Strict: true}, or create the vat.Validator with
+// vat.WithSkipCheckDigits, when using TestServiceBaseURL.
 package ukvat
 
 import (
@@ -8,10 +13,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/creativefabrica/vat"
+	"github.com/creativefabrica/vat/internal/redact"
 )
 
 // API Documentation:
@@ -38,6 +45,7 @@ type Client struct {
 	token       string
 	expiry      time.Time
 	mutex       sync.Mutex
+	requester   string
 }
 
 func WithHTTPClient(client *http.Client) ClientOption {
@@ -52,6 +60,14 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithRequester sets the VAT number of the party doing the lookup.
+// HMRC only issues a consultation number when a requester is provided.
+func WithRequester(id vat.IDNumber) ClientOption {
+	return func(c *Client) {
+		c.requester = id.Number
+	}
+}
+
 type ClientOption func(*Client)
 
 func NewClient(creds ClientCredentials, options ...ClientOption) *Client {
@@ -132,7 +148,61 @@ func (c *Client) Authenticate(ctx context.Context) error {
 	return nil
 }
 
+type lookupAddress struct {
+	Line1       string `json:"line1"`
+	Line2       string `json:"line2"`
+	Line3       string `json:"line3"`
+	Line4       string `json:"line4"`
+	Line5       string `json:"line5"`
+	Postcode    string `json:"postcode"`
+	CountryCode string `json:"countryCode"`
+}
+
+func (a lookupAddress) String() string {
+	parts := []string{a.Line1, a.Line2, a.Line3, a.Line4, a.Line5, a.Postcode, a.CountryCode}
+	lines := parts[:0]
+	for _, line := range parts {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type lookupResponse struct {
+	Target struct {
+		Name      string        `json:"name"`
+		VATNumber string        `json:"vatNumber"`
+		Address   lookupAddress `json:"address"`
+	} `json:"target"`
+	ProcessingDate     string `json:"processingDate"`
+	ConsultationNumber string `json:"consultationNumber"`
+}
+
+// CanVerify reports whether HMRC can verify the kind of the given UK VAT number.
+// Only standard and branch trader numbers are held in the HMRC register.
+func CanVerify(id vat.IDNumber) bool {
+	kind := id.UKKind()
+
+	return kind == vat.UKKindStandard || kind == vat.UKKindBranchTrader
+}
+
 func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
+	_, err := c.ValidateDetailed(ctx, id)
+
+	return err
+}
+
+// ValidateDetailed returns whether the given VAT number is valid or not,
+// together with the registered name and address HMRC holds for it.
+func (c *Client) ValidateDetailed(ctx context.Context, id vat.IDNumber) (vat.ValidationResult, error) {
+	result := vat.ValidationResult{ID: id, Provider: vat.ProviderHMRC}
+
+	if kind := id.UKKind(); kind == vat.UKKindGovernmentDepartment || kind == vat.UKKindHealthAuthority {
+		return result, fmt.Errorf("%w: %s", ErrUnverifiableKind, kind)
+	}
+
 	// Check if token needs to be refreshed
 	c.mutex.Lock()
 	needsAuth := time.Now().After(c.expiry.Add(-2 * time.Minute))
@@ -141,7 +211,7 @@ func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
 	if needsAuth {
 		err := c.Authenticate(ctx)
 		if err != nil {
-			return err
+			return result, err
 		}
 	}
 
@@ -151,10 +221,17 @@ func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
 	c.mutex.Unlock()
 
 	url := fmt.Sprintf("%s/organisations/vat/check-vat-number/lookup/%s", c.baseURL, id.Number)
+	if c.requester != "" {
+		url += "/" + c.requester
+	}
+
+	// The URL holds the VAT number, so it is masked in errors.
+	maskedURL := fmt.Sprintf("%s/organisations/vat/check-vat-number/lookup/%s",
+		c.baseURL, strings.TrimPrefix(id.Masked(), id.CountryCode))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, redact.URL(err, maskedURL))
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -162,29 +239,46 @@ func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, redact.URL(err, maskedURL))
 	}
 	defer res.Body.Close()
 
 	switch res.StatusCode {
 	case http.StatusUnauthorized:
-		return errors.Join(
+		return result, errors.Join(
 			vat.ErrServiceUnavailable,
 			errors.New("unauthorized request to UK VAT API"),
 		)
 	case http.StatusBadRequest:
-		return vat.ErrInvalidFormat
+		return result, vat.ErrInvalidFormat
 	case http.StatusNotFound:
-		return vat.ErrNotFound
+		return result, vat.ErrNotFound
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return errors.Join(
+		return result, errors.Join(
 			vat.ErrServiceUnavailable,
 			fmt.Errorf("unexpected status code from UK VAT API: %d", res.StatusCode),
 		)
 	}
 
+	var lookup lookupResponse
+
+	err = json.NewDecoder(res.Body).Decode(&lookup)
+	if err != nil {
+		return result, errors.Join(
+			vat.ErrServiceUnavailable,
+			fmt.Errorf("failed to decode UK VAT API response: %w", err),
+		)
+	}
+
 	// If we receive a valid 200 response from this API, it means the VAT number exists and is valid
-	return nil
+	result.Valid = true
+	result.Outcome = vat.OutcomeRegistryConfirmed
+	result.Name = lookup.Target.Name
+	result.Address = lookup.Target.Address.String()
+	result.ConsultationNumber = lookup.ConsultationNumber
+	result.RequestDate, _ = time.Parse(time.RFC3339, lookup.ProcessingDate)
+
+	return result, nil
 }
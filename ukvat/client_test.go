@@ -1,15 +1,32 @@
 package ukvat_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/creativefabrica/vat"
 	"github.com/creativefabrica/vat/ukvat"
 )
 
+// sandboxNumber parses a test number of the HMRC sandbox, which has no valid check digits.
+func sandboxNumber(t *testing.T, s string) vat.IDNumber {
+	t.Helper()
+
+	_, err := vat.Parse(s)
+	require.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+
+	id, err := vat.ParseWithOptions(s, vat.ParseOptions{SkipCheckDigits: true})
+	require.NoError(t, err)
+
+	return id
+}
+
 func TestClient_Validate(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -20,7 +37,7 @@ func TestClient_Validate(t *testing.T) {
 		{
 			name:      "Missing credentials",
 			creds:     ukvat.ClientCredentials{},
-			vatNumber: vat.MustParse("GB123456789"),
+			vatNumber: vat.MustParse("GB980780684"),
 			wantErr:   vat.ErrServiceUnavailable,
 		},
 		{
@@ -29,7 +46,7 @@ func TestClient_Validate(t *testing.T) {
 				ID:     os.Getenv("UKVAT_API_CLIENT_ID"),
 				Secret: os.Getenv("UKVAT_API_CLIENT_SECRET"),
 			},
-			vatNumber: vat.MustParse("GB146295999727"),
+			vatNumber: sandboxNumber(t, "GB146295999727"),
 			wantErr:   nil,
 		},
 	}
@@ -44,3 +61,111 @@ func TestClient_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ValidateDetailed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 14400}`))
+	})
+	mux.HandleFunc(
+		"GET /organisations/vat/check-vat-number/lookup/146295999727/553557881",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{
+				"target": {
+					"name": "Credite Sberger Donal Inc.",
+					"vatNumber": "146295999727",
+					"address": {
+						"line1": "131B Barton Hamlet",
+						"line2": "",
+						"postcode": "SW97 5CK",
+						"countryCode": "GB"
+					}
+				},
+				"requester": "553557881",
+				"consultationNumber": "Syb-xyz-abc",
+				"processingDate": "2025-05-07T10:08:55+01:00"
+			}`))
+		},
+	)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	target := sandboxNumber(t, "GB146295999727")
+	c := ukvat.NewClient(
+		ukvat.ClientCredentials{ID: "id", Secret: "secret"},
+		ukvat.WithBaseURL(server.URL),
+		ukvat.WithRequester(sandboxNumber(t, "GB553557881")),
+	)
+	got, err := c.ValidateDetailed(t.Context(), target)
+	require.NoError(t, err)
+	assert.Equal(t, vat.ValidationResult{
+		ID:                 target,
+		Valid:              true,
+		Outcome:            vat.OutcomeRegistryConfirmed,
+		Name:               "Credite Sberger Donal Inc.",
+		Address:            "131B Barton Hamlet\nSW97 5CK\nGB",
+		RequestDate:        time.Date(2025, 5, 7, 10, 8, 55, 0, time.FixedZone("", 3600)),
+		ConsultationNumber: "Syb-xyz-abc",
+		Provider:           vat.ProviderHMRC,
+	}, got)
+}
+
+func TestValidator_Sandbox(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 14400}`))
+	})
+	mux.HandleFunc(
+		"GET /organisations/vat/check-vat-number/lookup/146295999727",
+		func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"target": {"name": "Credite Sberger Donal Inc.", "vatNumber": "146295999727"}}`))
+		},
+	)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := ukvat.NewClient(ukvat.ClientCredentials{ID: "id", Secret: "secret"}, ukvat.WithBaseURL(server.URL))
+
+	err := vat.NewValidator(vat.WithUKVATClient(client)).Validate(t.Context(), "GB146295999727")
+	require.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+
+	validator := vat.NewValidator(vat.WithUKVATClient(client), vat.WithSkipCheckDigits())
+	got, err := validator.ValidateDetailed(t.Context(), "GB146295999727")
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, "Credite Sberger Donal Inc.", got.Name)
+
+	// Parsing stays strict, only the check digits are skipped.
+	err = validator.Validate(t.Context(), "GB146-295-999-727")
+	require.ErrorIs(t, err, vat.ErrInvalidFormat)
+}
+
+func TestCanVerify(t *testing.T) {
+	assert.True(t, ukvat.CanVerify(vat.MustParse("GB980780684")))
+	assert.True(t, ukvat.CanVerify(vat.MustParse("GB980780684001")))
+	assert.False(t, ukvat.CanVerify(vat.MustParse("GBGD001")))
+	assert.False(t, ukvat.CanVerify(vat.MustParse("GBHA599")))
+
+	c := ukvat.NewClient(ukvat.ClientCredentials{})
+	err := c.Validate(t.Context(), vat.MustParse("GBGD001"))
+	assert.ErrorIs(t, err, ukvat.ErrUnverifiableKind)
+}
+
+func TestClient_ValidateDetailed_ErrorsDoNotLeakNumber(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 14400}`))
+	})
+	mux.HandleFunc("GET /organisations/vat/check-vat-number/lookup/", func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := ukvat.NewClient(ukvat.ClientCredentials{ID: "id", Secret: "secret"}, ukvat.WithBaseURL(server.URL))
+	_, err := c.ValidateDetailed(t.Context(), vat.MustParse("GB980780684"))
+	require.ErrorIs(t, err, vat.ErrServiceUnavailable)
+	assert.NotContains(t, err.Error(), "980780684")
+	assert.Contains(t, err.Error(), "*****0684")
+}
@@ -0,0 +1,7 @@
+package ukvat
+
+import "errors"
+
+// ErrUnverifiableKind is returned for government department and health authority numbers,
+// which the HMRC API does not know about.
+var ErrUnverifiableKind = errors.New("uk vat number kind cannot be verified online")
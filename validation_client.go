@@ -5,3 +5,9 @@ import "context"
 type ValidationClient interface {
 	Validate(ctx context.Context, id IDNumber) error
 }
+
+// DetailedValidationClient is a ValidationClient that can also return the details of the registry lookup.
+type DetailedValidationClient interface {
+	ValidationClient
+	ValidateDetailed(ctx context.Context, id IDNumber) (ValidationResult, error)
+}
@@ -0,0 +1,36 @@
+package vat
+
+import "time"
+
+// Outcome tells how far a VAT number could be validated.
+type Outcome string
+
+const (
+	// OutcomeFormatOnly means only the format was checked, because no client is routed for the country.
+	OutcomeFormatOnly Outcome = "format_only"
+	// OutcomeRegistryConfirmed means a registry confirmed the VAT number exists.
+	OutcomeRegistryConfirmed Outcome = "registry_confirmed"
+)
+
+// ValidationResult holds everything a registry told us about a VAT number.
+// Fields the provider does not return are left empty.
+type ValidationResult struct {
+	// ID is the parsed VAT number that was looked up.
+	ID IDNumber
+	// Valid reports whether the VAT number passed validation. Check Outcome to see whether a registry confirmed it.
+	Valid bool
+	// Outcome tells whether the number was confirmed by a registry or only format checked.
+	// It is empty when validation failed.
+	Outcome Outcome
+	// Name is the registered name of the trader.
+	Name string
+	// Address is the registered address of the trader, one line per address line.
+	Address string
+	// RequestDate is the timestamp the registry reported for the lookup.
+	RequestDate time.Time
+	// ConsultationNumber is the reference the registry issued for the lookup, if any.
+	// VIES and HMRC only return it when a requester VAT number is configured on the client.
+	ConsultationNumber string
+	// Provider is the registry that answered.
+	Provider Provider
+}
@@ -2,36 +2,80 @@ package vat
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 )
 
+// Validator routes VAT numbers to the validation client registered for their country.
 type Validator struct {
-	viesClient  ValidationClient
-	ukVATClient ValidationClient
-	abnClient   ValidationClient
+	routes             map[string]ValidationClient
+	requireOnlineCheck bool
+	formatOnlyFallback bool
+	parseOptions       ParseOptions
 }
 
+// ValidatorOption configures a Validator.
+// Options are applied in order, so a later option overrides the routes set by an earlier one.
 type ValidatorOption func(*Validator)
 
-func WithViesClient(client ValidationClient) ValidatorOption {
+// WithClientForCountries routes VAT numbers of the given countries to client.
+// Use this to plug in national services, or to route XI numbers to HMRC instead of VIES.
+func WithClientForCountries(client ValidationClient, countryCodes ...string) ValidatorOption {
 	return func(v *Validator) {
-		v.viesClient = client
+		for _, countryCode := range countryCodes {
+			v.routes[countryCode] = client
+		}
 	}
 }
 
+// WithViesClient routes every country VIES can verify, including XI, to client.
+func WithViesClient(client ValidationClient) ValidatorOption {
+	return WithClientForCountries(client, defaultCountries(ProviderVIES)...)
+}
+
+// WithUKVATClient routes GB numbers to client.
 func WithUKVATClient(client ValidationClient) ValidatorOption {
+	return WithClientForCountries(client, defaultCountries(ProviderHMRC)...)
+}
+
+// WithANBClient routes AU numbers to client.
+func WithANBClient(client ValidationClient) ValidatorOption {
+	return WithClientForCountries(client, defaultCountries(ProviderABR)...)
+}
+
+// WithRequireOnlineCheck makes a Validator without any client fail with ErrNoValidationClient too,
+// instead of only checking the format of every number.
+func WithRequireOnlineCheck() ValidatorOption {
 	return func(v *Validator) {
-		v.ukVATClient = client
+		v.requireOnlineCheck = true
+		v.formatOnlyFallback = false
 	}
 }
 
-func WithANBClient(client ValidationClient) ValidatorOption {
+// WithFormatOnlyFallback makes the Validator only check the format of the numbers of countries no client is routed for,
+// reporting them valid with OutcomeFormatOnly, instead of failing with ErrNoValidationClient or ErrUnsupportedCountry.
+// Use it when you only need to verify some countries, e.g. the EU ones with VIES, and accept the others as typed.
+func WithFormatOnlyFallback() ValidatorOption {
+	return func(v *Validator) {
+		v.formatOnlyFallback = true
+		v.requireOnlineCheck = false
+	}
+}
+
+// WithSkipCheckDigits makes the Validator accept numbers whose check digits don't match, parsing them otherwise
+// like Parse does, e.g. to send the test numbers of the HMRC sandbox to a client using ukvat.TestServiceBaseURL.
+func WithSkipCheckDigits() ValidatorOption {
 	return func(v *Validator) {
-		v.abnClient = client
+		v.parseOptions.SkipCheckDigits = true
 	}
 }
 
 func NewValidator(options ...ValidatorOption) *Validator {
-	v := &Validator{}
+	v := &Validator{
+		routes:       make(map[string]ValidationClient),
+		parseOptions: ParseOptions{Strict: true},
+	}
 	for _, option := range options {
 		option(v)
 	}
@@ -39,31 +83,63 @@ func NewValidator(options ...ValidatorOption) *Validator {
 	return v
 }
 
+// CanValidate reports whether a client is routed for the given country.
+func (v *Validator) CanValidate(countryCode string) bool {
+	_, ok := v.routes[countryCode]
+
+	return ok
+}
+
+// Countries returns the sorted country codes a client is routed for.
+func (v *Validator) Countries() []string {
+	return slices.Sorted(maps.Keys(v.routes))
+}
+
 // Validate checks the format of a VAT number, and its existence only if the respective client is present.
 func (v *Validator) Validate(ctx context.Context, vatNumber string) error {
-	id, err := Parse(vatNumber)
+	_, err := v.ValidateDetailed(ctx, vatNumber)
+
+	return err
+}
+
+// ValidateDetailed works like Validate but also returns the details reported by the registry.
+// For clients that do not implement DetailedValidationClient only ID and Valid are populated.
+//
+// If no client is routed for the country ErrNoValidationClient is returned, as silently accepting the number
+// would hide a missing route, or ErrUnsupportedCountry when no service can verify the country at all,
+// see ProvidersFor.
+// With WithFormatOnlyFallback the number is only format checked instead, and the result has OutcomeFormatOnly.
+// A Validator without any client only checks the format of every number, unless created WithRequireOnlineCheck.
+func (v *Validator) ValidateDetailed(ctx context.Context, vatNumber string) (ValidationResult, error) {
+	parsed, err := ParseWithOptions(vatNumber, v.parseOptions)
 	if err != nil {
-		return err
+		return ValidationResult{}, err
 	}
 
-	switch id.CountryCode {
-	case "AU":
-		if v.abnClient == nil {
-			return nil
-		}
+	// Registries only know the canonical form, e.g. EL instead of GR.
+	id := parsed.Canonical()
 
-		return v.abnClient.Validate(ctx, id)
-	case "GB":
-		if v.ukVATClient == nil {
-			return nil
+	client, routed := v.routes[id.CountryCode]
+	if !routed {
+		if v.formatOnlyFallback || len(v.routes) == 0 && !v.requireOnlineCheck {
+			return ValidationResult{ID: id, Valid: true, Outcome: OutcomeFormatOnly}, nil
 		}
 
-		return v.ukVATClient.Validate(ctx, id)
-	default:
-		if v.viesClient == nil {
-			return nil
+		if len(ProvidersFor(id.CountryCode)) == 0 {
+			return ValidationResult{ID: id}, fmt.Errorf("%w: %s", ErrUnsupportedCountry, id.CountryCode)
 		}
 
-		return v.viesClient.Validate(ctx, id)
+		return ValidationResult{ID: id}, fmt.Errorf("%w: %s", ErrNoValidationClient, id.CountryCode)
+	}
+
+	if detailed, ok := client.(DetailedValidationClient); ok {
+		return detailed.ValidateDetailed(ctx, id)
 	}
+
+	err = client.Validate(ctx, id)
+	if err != nil {
+		return ValidationResult{ID: id}, err
+	}
+
+	return ValidationResult{ID: id, Valid: true, Outcome: OutcomeRegistryConfirmed}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/creativefabrica/vat"
 	"github.com/creativefabrica/vat/vattest"
@@ -60,3 +61,171 @@ func TestValidator_Validate(t *testing.T) {
 		assert.ErrorIs(t, err, vat.ErrInvalidFormat)
 	})
 }
+
+func TestValidator_ValidateDetailed(t *testing.T) {
+	t.Run("detailed client", func(t *testing.T) {
+		ctx := t.Context()
+		client := vattest.NewMockDetailedValidationClient(t)
+		validator := vat.NewValidator(vat.WithViesClient(client))
+		id := vat.MustParse("NL822010690B01")
+		want := vat.ValidationResult{
+			ID:       id,
+			Valid:    true,
+			Name:     "CREATIVE FABRICA B.V.",
+			Provider: vat.ProviderVIES,
+		}
+		client.EXPECT().ValidateDetailed(ctx, id).Return(want, nil)
+		got, err := validator.ValidateDetailed(ctx, id.String())
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("plain client", func(t *testing.T) {
+		ctx := t.Context()
+		client := vattest.NewMockValidationClient(t)
+		validator := vat.NewValidator(vat.WithViesClient(client))
+		id := vat.MustParse("NL822010690B01")
+		client.EXPECT().Validate(ctx, id).Return(vat.ErrNotFound)
+		got, err := validator.ValidateDetailed(ctx, id.String())
+		require.ErrorIs(t, err, vat.ErrNotFound)
+		assert.Equal(t, vat.ValidationResult{ID: id}, got)
+	})
+
+	t.Run("plain client confirms", func(t *testing.T) {
+		ctx := t.Context()
+		client := vattest.NewMockValidationClient(t)
+		validator := vat.NewValidator(vat.WithViesClient(client))
+		id := vat.MustParse("NL822010690B01")
+		client.EXPECT().Validate(ctx, id).Return(nil)
+		got, err := validator.ValidateDetailed(ctx, id.String())
+		require.NoError(t, err)
+		assert.Equal(t, vat.ValidationResult{ID: id, Valid: true, Outcome: vat.OutcomeRegistryConfirmed}, got)
+	})
+
+	t.Run("no client", func(t *testing.T) {
+		got, err := vat.NewValidator().ValidateDetailed(t.Context(), "GB980780684")
+		require.NoError(t, err)
+		assert.Equal(t, vat.ValidationResult{
+			ID:      vat.MustParse("GB980780684"),
+			Valid:   true,
+			Outcome: vat.OutcomeFormatOnly,
+		}, got)
+	})
+}
+
+func TestValidator_RequireOnlineCheck(t *testing.T) {
+	viesClient := vattest.NewMockValidationClient(t)
+
+	t.Run("missing client", func(t *testing.T) {
+		validator := vat.NewValidator(
+			vat.WithViesClient(viesClient),
+			vat.WithRequireOnlineCheck(),
+		)
+		got, err := validator.ValidateDetailed(t.Context(), "GB980780684")
+		require.ErrorIs(t, err, vat.ErrNoValidationClient)
+		assert.False(t, got.Valid)
+	})
+
+	t.Run("no clients at all", func(t *testing.T) {
+		validator := vat.NewValidator(vat.WithRequireOnlineCheck())
+		err := validator.Validate(t.Context(), "NL822010690B01")
+		assert.ErrorIs(t, err, vat.ErrNoValidationClient)
+	})
+
+	t.Run("routed client", func(t *testing.T) {
+		ctx := t.Context()
+		validator := vat.NewValidator(
+			vat.WithViesClient(viesClient),
+			vat.WithRequireOnlineCheck(),
+		)
+		id := vat.MustParse("NL822010690B01")
+		viesClient.EXPECT().Validate(ctx, id).Return(nil).Once()
+		err := validator.Validate(ctx, id.String())
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidator_Routing(t *testing.T) {
+	viesClient := vattest.NewMockValidationClient(t)
+	ukVATClient := vattest.NewMockValidationClient(t)
+	swissClient := vattest.NewMockValidationClient(t)
+
+	t.Run("built-in routes", func(t *testing.T) {
+		validator := vat.NewValidator(
+			vat.WithViesClient(viesClient),
+			vat.WithUKVATClient(ukVATClient),
+		)
+		assert.True(t, validator.CanValidate("NL"))
+		assert.True(t, validator.CanValidate("XI"))
+		assert.True(t, validator.CanValidate("GB"))
+		assert.False(t, validator.CanValidate("AU"))
+		assert.False(t, validator.CanValidate("CH"))
+		assert.Len(t, validator.Countries(), 29)
+	})
+
+	t.Run("XI routed to HMRC", func(t *testing.T) {
+		ctx := t.Context()
+		validator := vat.NewValidator(
+			vat.WithViesClient(viesClient),
+			vat.WithClientForCountries(ukVATClient, "GB", "XI"),
+		)
+		id := vat.MustParse("XI980780684")
+		ukVATClient.EXPECT().Validate(ctx, id).Return(nil).Once()
+		err := validator.Validate(ctx, id.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom client for CH", func(t *testing.T) {
+		ctx := t.Context()
+		validator := vat.NewValidator(
+			vat.WithViesClient(viesClient),
+			vat.WithClientForCountries(swissClient, "CH"),
+		)
+		id := vat.MustParse("CHE123456789")
+		swissClient.EXPECT().Validate(ctx, id).Return(nil).Once()
+		err := validator.Validate(ctx, id.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("unrouted country without service", func(t *testing.T) {
+		validator := vat.NewValidator(vat.WithViesClient(viesClient))
+		ids := []string{
+			"CHE123456789", "NO974760673MVA", "IS123456", "LI54321", "SM24165", "ADU132950X", "RU7707083893", "US521234567",
+		}
+		for _, id := range ids {
+			err := validator.Validate(t.Context(), id)
+			assert.ErrorIs(t, err, vat.ErrUnsupportedCountry, id)
+		}
+	})
+
+	t.Run("unrouted country with service", func(t *testing.T) {
+		validator := vat.NewValidator(vat.WithViesClient(viesClient))
+		err := validator.Validate(t.Context(), "GB980780684")
+		assert.ErrorIs(t, err, vat.ErrNoValidationClient)
+	})
+
+	t.Run("format only fallback", func(t *testing.T) {
+		validator := vat.NewValidator(vat.WithViesClient(viesClient), vat.WithFormatOnlyFallback())
+		for _, id := range []string{"GB980780684", "CHE123456789"} {
+			got, err := validator.ValidateDetailed(t.Context(), id)
+			require.NoError(t, err, id)
+			assert.Equal(t, vat.OutcomeFormatOnly, got.Outcome, id)
+		}
+
+		err := validator.Validate(t.Context(), "GB980780685")
+		assert.ErrorIs(t, err, vat.ErrInvalidCheckDigits)
+	})
+
+	t.Run("format only validator", func(t *testing.T) {
+		err := vat.NewValidator().Validate(t.Context(), "CHE123456789")
+		assert.NoError(t, err)
+	})
+}
+
+func TestProvidersFor(t *testing.T) {
+	assert.Equal(t, []vat.Provider{vat.ProviderVIES}, vat.ProvidersFor("NL"))
+	assert.Equal(t, []vat.Provider{vat.ProviderVIES, vat.ProviderHMRC}, vat.ProvidersFor("XI"))
+	assert.Equal(t, []vat.Provider{vat.ProviderHMRC}, vat.ProvidersFor("GB"))
+	assert.Empty(t, vat.ProvidersFor("CH"))
+	assert.Empty(t, vat.ProvidersFor("NO"))
+}
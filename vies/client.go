@@ -18,6 +18,7 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	retries    int
+	requester  *vat.IDNumber
 }
 
 func WithHTTPClient(client *http.Client) ClientOption {
@@ -32,6 +33,21 @@ func WithRetries(retries int) ClientOption {
 	}
 }
 
+// WithBaseURL overrides the VIES API base URL.
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithRequester sets the VAT number of the party doing the lookup.
+// VIES only issues a consultation number when a requester is provided.
+func WithRequester(id vat.IDNumber) ClientOption {
+	return func(c *Client) {
+		c.requester = &id
+	}
+}
+
 type ClientOption func(*Client)
 
 func NewClient(options ...ClientOption) *Client {
@@ -52,25 +68,42 @@ type responseError struct {
 }
 
 type response struct {
-	ActionSucceed *bool           `json:"actionSucceed"`
-	ErrorWrappers []responseError `json:"errorWrappers"`
-	CountryCode   string          `json:"countryCode"`
-	VATNumber     string          `json:"vatNumber"`
-	RequestDate   string          `json:"requestDate"`
-	Valid         bool            `json:"valid"`
+	ActionSucceed     *bool           `json:"actionSucceed"`
+	ErrorWrappers     []responseError `json:"errorWrappers"`
+	CountryCode       string          `json:"countryCode"`
+	VATNumber         string          `json:"vatNumber"`
+	RequestDate       string          `json:"requestDate"`
+	Valid             bool            `json:"valid"`
+	RequestIdentifier string          `json:"requestIdentifier"`
+	Name              string          `json:"name"`
+	Address           string          `json:"address"`
 }
 
+// notAvailable is the placeholder VIES returns for trader details a member state does not share.
+const notAvailable = "---"
+
 // Validate returns whether the given VAT number is valid or not.
 func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
+	_, err := c.ValidateDetailed(ctx, id)
+
+	return err
+}
+
+// ValidateDetailed returns whether the given VAT number is valid or not,
+// together with the trader details VIES shares for it.
+func (c *Client) ValidateDetailed(ctx context.Context, id vat.IDNumber) (vat.ValidationResult, error) {
 	if c.retries == 0 {
 		return c.validate(ctx, id)
 	}
 
-	var err error
+	var (
+		result vat.ValidationResult
+		err    error
+	)
 	for i := range c.retries {
-		err = c.validate(ctx, id)
+		result, err = c.validate(ctx, id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 
 		if errors.Is(err, vat.ErrInvalidFormat) || errors.Is(err, vat.ErrNotFound) {
@@ -84,18 +117,26 @@ func (c *Client) Validate(ctx context.Context, id vat.IDNumber) error {
 		err = vat.ErrServiceUnavailable
 	}
 
-	return err
+	return result, err
 }
 
-func (c *Client) validate(ctx context.Context, id vat.IDNumber) error {
+//nolint:cyclop // Maps every VIES failure mode to the package errors.
+func (c *Client) validate(ctx context.Context, id vat.IDNumber) (vat.ValidationResult, error) {
+	result := vat.ValidationResult{ID: id, Provider: vat.ProviderVIES}
+	id = id.Canonical()
 	payload := map[string]string{
 		"countryCode": id.CountryCode,
 		"vatNumber":   id.Number,
 	}
+	if c.requester != nil {
+		requester := c.requester.Canonical()
+		payload["requesterMemberStateCode"] = requester.CountryCode
+		payload["requesterNumber"] = requester.Number
+	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	url := c.baseURL + "/check-vat-number"
@@ -106,7 +147,7 @@ func (c *Client) validate(ctx context.Context, id vat.IDNumber) error {
 		bytes.NewBuffer(jsonData),
 	)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	req.Header.Set("accept", "application/json")
@@ -114,7 +155,7 @@ func (c *Client) validate(ctx context.Context, id vat.IDNumber) error {
 
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 	defer func() {
 		_ = res.Body.Close()
@@ -122,44 +163,56 @@ func (c *Client) validate(ctx context.Context, id vat.IDNumber) error {
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	if res.StatusCode == http.StatusBadRequest {
-		return vat.ErrInvalidFormat
+		return result, vat.ErrInvalidFormat
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return vat.ErrServiceUnavailable
+		return result, vat.ErrServiceUnavailable
 	}
 
 	var resp response
 	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		return errors.Join(vat.ErrServiceUnavailable, err)
+		return result, errors.Join(vat.ErrServiceUnavailable, err)
 	}
 
 	if resp.ActionSucceed != nil && *resp.ActionSucceed == false {
 		if len(resp.ErrorWrappers) == 0 {
-			return vat.ErrServiceUnavailable
+			return result, vat.ErrServiceUnavailable
 		}
 
 		errorCode := resp.ErrorWrappers[0].Error
 		switch errorCode {
 		case "INVALID_INPUT":
-			return vat.ErrInvalidFormat
+			return result, vat.ErrInvalidFormat
 		case "MS_UNAVAILABLE":
-			return vat.ErrServiceUnavailable
+			return result, vat.ErrServiceUnavailable
 		case "MS_MAX_CONCURRENT_REQ":
-			return errRateLimitExceeded
+			return result, errRateLimitExceeded
 		default:
-			return vat.ErrServiceUnavailable
+			return result, vat.ErrServiceUnavailable
 		}
 	}
 
+	result.ConsultationNumber = resp.RequestIdentifier
+	result.RequestDate, _ = time.Parse(time.RFC3339, resp.RequestDate)
+	if resp.Name != notAvailable {
+		result.Name = resp.Name
+	}
+	if resp.Address != notAvailable {
+		result.Address = resp.Address
+	}
+
 	if !resp.Valid {
-		return vat.ErrNotFound
+		return result, vat.ErrNotFound
 	}
 
-	return nil
+	result.Valid = true
+	result.Outcome = vat.OutcomeRegistryConfirmed
+
+	return result, nil
 }
@@ -1,9 +1,14 @@
 package vies_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/creativefabrica/vat"
 	"github.com/creativefabrica/vat/vies"
@@ -39,3 +44,40 @@ func Test_Client_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_Client_ValidateDetailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		assert.Equal(t, "DE", payload["requesterMemberStateCode"])
+		assert.Equal(t, "123456789", payload["requesterNumber"])
+
+		_, _ = w.Write([]byte(`{
+			"countryCode": "NL",
+			"vatNumber": "822010690B01",
+			"requestDate": "2025-05-07T10:08:55.512Z",
+			"valid": true,
+			"requestIdentifier": "WAPIAAAAWdVkWyUx",
+			"name": "CREATIVE FABRICA B.V.",
+			"address": "STRAWINSKYLAAN 00001\n1077XX AMSTERDAM"
+		}`))
+	}))
+	defer server.Close()
+
+	c := vies.NewClient(
+		vies.WithBaseURL(server.URL),
+		vies.WithRequester(vat.IDNumber{CountryCode: "DE", Number: "123456789"}),
+	)
+	got, err := c.ValidateDetailed(t.Context(), vat.MustParse("NL822010690B01"))
+	require.NoError(t, err)
+	assert.Equal(t, vat.ValidationResult{
+		ID:                 vat.MustParse("NL822010690B01"),
+		Valid:              true,
+		Outcome:            vat.OutcomeRegistryConfirmed,
+		Name:               "CREATIVE FABRICA B.V.",
+		Address:            "STRAWINSKYLAAN 00001\n1077XX AMSTERDAM",
+		RequestDate:        time.Date(2025, 5, 7, 10, 8, 55, 512000000, time.UTC),
+		ConsultationNumber: "WAPIAAAAWdVkWyUx",
+		Provider:           vat.ProviderVIES,
+	}, got)
+}